@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"github.com/TypicalAM/gopoker/models"
+	"github.com/TypicalAM/gopoker/services/export"
+	"gorm.io/gorm"
+)
+
+// exportBatchSize is the number of hands loaded from the database at once.
+const exportBatchSize = 100
+
+// exportHands converts the stored hands into a hand history file.
+// Usage: gopoker export [-format pokerstars|json] [-user username] [-game uuid] [-out file]
+func exportHands(db *gorm.DB, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	formatName := flags.String("format", string(export.PokerStars), "the output format, pokerstars or json")
+	username := flags.String("user", "", "export only the hands of this user, with their hole cards shown")
+	gameUUID := flags.String("game", "", "export only the hands of this game")
+	out := flags.String("out", "", "the output file, the standard output is used if empty")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	format, err := export.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	query := db.Model(&models.Hand{}).Preload("Seats", func(db *gorm.DB) *gorm.DB {
+		return db.Order("seat")
+	}).Preload("Actions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("sequence")
+	}).Preload("Pots")
+
+	var user models.User
+	if *username != "" {
+		if res := db.Where("username = ?", *username).First(&user); res.Error != nil {
+			return res.Error
+		}
+
+		query = query.Joins("JOIN hand_seats ON hand_seats.hand_id = hands.id AND hand_seats.deleted_at IS NULL").
+			Where("hand_seats.user_id = ?", user.ID)
+	}
+
+	if *gameUUID != "" {
+		query = query.Where("hands.game_uuid = ?", *gameUUID)
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+
+		defer file.Close()
+		w = file
+	}
+
+	writer, err := export.NewWriter(w, format, user.Username)
+	if err != nil {
+		return err
+	}
+
+	var hands []models.Hand
+	res := query.FindInBatches(&hands, exportBatchSize, func(tx *gorm.DB, batch int) error {
+		for i := range hands {
+			if err := writer.Write(hands[i].Sanitize(user.ID)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	log.Printf("Exported %d hands", writer.Count())
+	return nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"os"
 	"time"
 
 	"github.com/TypicalAM/gopoker/config"
@@ -29,6 +30,24 @@ func main() {
 		log.Fatal(err)
 	}
 
+	// Run the export subcommand instead of the server if requested
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err = exportHands(db, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
+	// Check the shuffle of a stored hand instead of running the server if requested
+	if len(os.Args) > 1 && os.Args[1] == "verify" {
+		if err = verifyHand(db, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
 	// Set up the file service
 	var uploader upload.Uploader
 	switch cfg.FileUploadType {
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/TypicalAM/gopoker/models"
+	"github.com/TypicalAM/gopoker/texas"
+	"gorm.io/gorm"
+)
+
+// verifyHand recomputes the deck of a stored hand from its seeds and checks the
+// commitment and every dealt card against it. The server seed never leaves the
+// database, so the hands are verified here or through /api/hands/:id/verify.
+// Usage: gopoker verify -hand id
+func verifyHand(db *gorm.DB, args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	handID := flags.Uint("hand", 0, "the id of the hand to verify")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *handID == 0 {
+		return errors.New("the hand to verify is required")
+	}
+
+	var hand models.Hand
+	res := db.Preload("Seats", func(db *gorm.DB) *gorm.DB {
+		return db.Order("seat")
+	}).First(&hand, *handID)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if hand.ServerSeed == "" {
+		return fmt.Errorf("%w: the hand has no server seed", texas.InvalidSeedErr)
+	}
+
+	deck, err := hand.Verify()
+	if deck != nil {
+		names := make([]string, len(deck))
+		for i, card := range deck {
+			names[i] = card.String()
+		}
+
+		fmt.Println("Deck:", strings.Join(names, " "))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Hand %d was dealt from the committed deck\n", hand.Number)
+	return nil
+}
@@ -29,7 +29,46 @@ type Config struct {
 	DatabaseName     string
 
 	// Game related
-	GamePlayerCap int
+	GamePlayerCap     int
+	GameMinPlayers    int
+	GameSmallBlind    int
+	GameBigBlind      int
+	GameAnte          int
+	GameStartingStack int
+	GameMinBuyIn      int
+	GameMaxBuyIn      int
+	GameActionTime    int
+	GameTimeBank      int
+
+	// Sit-and-go tournaments
+	SitAndGoBuyIn        int
+	SitAndGoStack        int
+	SitAndGoPlayers      int
+	SitAndGoLevelMinutes int
+
+	// The percentage of the buy-in every tournament entrant pays on top of it, the house keeps it as the rake
+	TournamentFee int
+
+	// Multi-table tournaments start once the entrants have registered, they
+	// play at the sit-and-go stakes with the number of players at every table
+	MultiTableEntrants int
+	MultiTablePlayers  int
+
+	// The strategy of the bots, the milliseconds they think about a move and the seconds
+	// a public cash table waits for players before the empty seats are filled with bots,
+	// zero seconds means the tables are never filled. The strategy can be a built-in one,
+	// "exec:" and the command of a bot process or the address of a local websocket bot,
+	// those fold if they don't answer in time
+	BotStrategy        string
+	BotThinkMillis     int
+	BotTimeoutMillis   int
+	BotBackfillSeconds int
+
+	// Seconds the spectators see the game behind the players
+	SpectatorDelay int
+
+	// Words masked in the table chat
+	ChatBannedWords []string
 
 	// Server related
 	ListenPort     string
@@ -46,36 +85,79 @@ type Config struct {
 // New returns a new Config struct.
 func New() *Config {
 	return &Config{
-		DatabaseUser:     getEnvString("DB_USER", "myuser"),
-		DatabasePassword: getEnvString("DB_PASSWORD", "mypassword"),
-		DatabaseHost:     getEnvString("DB_HOST", "localhost"),
-		DatabasePort:     getEnvString("DB_PORT", "5432"),
-		DatabaseName:     getEnvString("DB_DATABASE", "mydatabase"),
-		CookieSecret:     getEnvString("COOKIE_SECRET", "mysecret"),
-		RequestsPerMin:   getEnvInt("REQUESTS_PER_MIN", 30),
-		ListenPort:       getEnvString("LISTEN_PORT", "8080"),
-		GamePlayerCap:    getEnvInt("GAME_PLAYER_CAP", 3),
-		TrustedOrigins:   strings.Split(getEnvString("CORS_TRUSTED_ORIGINS", "http://localhost:3000"), ","),
-		FileUploadType:   getEnvFileUpload("FILE_UPLOAD_TYPE", Local),
-		CloudinaryURL:    getEnvString("CLOUDINARY_URL", ""),
-		FileUploadPath:   getEnvString("FILE_UPLOAD_PATH", "uploads"),
+		DatabaseUser:         getEnvString("DB_USER", "myuser"),
+		DatabasePassword:     getEnvString("DB_PASSWORD", "mypassword"),
+		DatabaseHost:         getEnvString("DB_HOST", "localhost"),
+		DatabasePort:         getEnvString("DB_PORT", "5432"),
+		DatabaseName:         getEnvString("DB_DATABASE", "mydatabase"),
+		CookieSecret:         getEnvString("COOKIE_SECRET", "mysecret"),
+		RequestsPerMin:       getEnvInt("REQUESTS_PER_MIN", 30),
+		ListenPort:           getEnvString("LISTEN_PORT", "8080"),
+		GamePlayerCap:        getEnvInt("GAME_PLAYER_CAP", 3),
+		GameMinPlayers:       getEnvInt("GAME_MIN_PLAYERS", 3),
+		GameSmallBlind:       getEnvInt("GAME_SMALL_BLIND", 1),
+		GameBigBlind:         getEnvInt("GAME_BIG_BLIND", 2),
+		GameAnte:             getEnvInt("GAME_ANTE", 0),
+		GameStartingStack:    getEnvInt("GAME_STARTING_STACK", 100),
+		GameMinBuyIn:         getEnvInt("GAME_MIN_BUY_IN", 40),
+		GameMaxBuyIn:         getEnvInt("GAME_MAX_BUY_IN", 200),
+		GameActionTime:       getEnvInt("GAME_ACTION_TIME", 30),
+		GameTimeBank:         getEnvInt("GAME_TIME_BANK", 60),
+		SitAndGoBuyIn:        getEnvInt("SNG_BUY_IN", 100),
+		SitAndGoStack:        getEnvInt("SNG_STACK", 1500),
+		SitAndGoPlayers:      getEnvInt("SNG_PLAYERS", 6),
+		SitAndGoLevelMinutes: getEnvInt("SNG_LEVEL_MINUTES", 5),
+		TournamentFee:        getEnvInt("TOURNAMENT_FEE", 0),
+		MultiTableEntrants:   getEnvInt("MTT_ENTRANTS", 18),
+		MultiTablePlayers:    getEnvInt("MTT_PLAYERS", 9),
+		BotStrategy:          getEnvString("BOT_STRATEGY", "tight-aggressive"),
+		BotThinkMillis:       getEnvInt("BOT_THINK_MS", 1500),
+		BotTimeoutMillis:     getEnvInt("BOT_TIMEOUT_MS", 5000),
+		BotBackfillSeconds:   getEnvInt("BOT_BACKFILL_SECONDS", 0),
+		SpectatorDelay:       getEnvInt("SPECTATOR_DELAY", 10),
+		ChatBannedWords:      strings.Split(getEnvString("CHAT_BANNED_WORDS", ""), ","),
+		TrustedOrigins:       strings.Split(getEnvString("CORS_TRUSTED_ORIGINS", "http://localhost:3000"), ","),
+		FileUploadType:       getEnvFileUpload("FILE_UPLOAD_TYPE", Local),
+		CloudinaryURL:        getEnvString("CLOUDINARY_URL", ""),
+		FileUploadPath:       getEnvString("FILE_UPLOAD_PATH", "uploads"),
 	}
 }
 
 // NewTest returns a new Config struct for testing.
 func NewTest() *Config {
 	return &Config{
-		DatabaseUser:     getEnvString("DB_USER", "myuser"),
-		DatabasePassword: getEnvString("DB_PASSWORD", "mypassword"),
-		DatabaseHost:     getEnvString("DB_TEST_HOST", "localhost"),
-		DatabasePort:     getEnvString("DB_PORT", "5432"),
-		DatabaseName:     getEnvString("DB_TEST_DATABASE", "mytestdatabase"),
-		CookieSecret:     "cokkie",
-		RequestsPerMin:   1000,
-		ListenPort:       "8080",
-		GamePlayerCap:    3,
-		TrustedOrigins:   strings.Split(getEnvString("CORS_TRUSTED_ORIGINS", "http://localhost:3000"), ","),
-		CloudinaryURL:    getEnvString("CLOUDINARY_URL", ""),
+		DatabaseUser:         getEnvString("DB_USER", "myuser"),
+		DatabasePassword:     getEnvString("DB_PASSWORD", "mypassword"),
+		DatabaseHost:         getEnvString("DB_TEST_HOST", "localhost"),
+		DatabasePort:         getEnvString("DB_PORT", "5432"),
+		DatabaseName:         getEnvString("DB_TEST_DATABASE", "mytestdatabase"),
+		CookieSecret:         "cokkie",
+		RequestsPerMin:       1000,
+		ListenPort:           "8080",
+		GamePlayerCap:        3,
+		GameMinPlayers:       3,
+		GameSmallBlind:       1,
+		GameBigBlind:         2,
+		GameAnte:             0,
+		GameStartingStack:    100,
+		GameMinBuyIn:         40,
+		GameMaxBuyIn:         200,
+		GameActionTime:       30,
+		GameTimeBank:         60,
+		SitAndGoBuyIn:        100,
+		SitAndGoStack:        1500,
+		SitAndGoPlayers:      3,
+		SitAndGoLevelMinutes: 5,
+		TournamentFee:        0,
+		MultiTableEntrants:   4,
+		MultiTablePlayers:    3,
+		BotStrategy:          "tight-aggressive",
+		BotThinkMillis:       0,
+		BotTimeoutMillis:     1000,
+		BotBackfillSeconds:   0,
+		SpectatorDelay:       0,
+		TrustedOrigins:       strings.Split(getEnvString("CORS_TRUSTED_ORIGINS", "http://localhost:3000"), ","),
+		CloudinaryURL:        getEnvString("CLOUDINARY_URL", ""),
 	}
 }
 
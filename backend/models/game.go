@@ -1,14 +1,112 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"crypto/rand"
+	"math/big"
+
+	"github.com/TypicalAM/gopoker/texas"
+	"gorm.io/gorm"
+)
 
 // GameIDKey is the key for the game ID in the session
 var GameIDKey = "gameID"
 
+// inviteAlphabet are the characters of an invite code, the ones easily confused are left out
+const inviteAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// inviteCodeLength is the length of an invite code
+const inviteCodeLength = 8
+
 // Game represents a game of poker
 type Game struct {
 	gorm.Model
 	Playing bool
 	UUID    string
 	Players []User
+
+	// Private tables only admit the holders of the invite code
+	Private    bool
+	InviteCode string `gorm:"index"`
+	OwnerID    uint
+
+	// Table stakes
+	SmallBlind    int
+	BigBlind      int
+	Ante          int
+	StartingStack int
+	MinBuyIn      int
+	MaxBuyIn      int
+	MinPlayers    int
+	PlayerCap     int
+	Structure     string
+
+	// Turn timers in seconds
+	ActionTime int
+	TimeBank   int
+
+	// Sit-and-go tournaments have a buy-in and blind levels
+	BuyIn        int
+	LevelMinutes int
+}
+
+// SetTableConfig stores the table config in the game.
+func (g *Game) SetTableConfig(config texas.TableConfig) {
+	g.SmallBlind = config.SmallBlind
+	g.BigBlind = config.BigBlind
+	g.Ante = config.Ante
+	g.StartingStack = config.StartingStack
+	g.MinBuyIn = config.MinBuyIn
+	g.MaxBuyIn = config.MaxBuyIn
+	g.MinPlayers = config.MinPlayers
+	g.PlayerCap = config.MaxPlayers
+	g.Structure = string(config.Structure)
+	g.ActionTime = config.ActionTime
+	g.TimeBank = config.TimeBank
+	g.BuyIn = config.BuyIn
+	g.LevelMinutes = config.LevelMinutes
+}
+
+// TableConfig returns the table config of the game.
+func (g *Game) TableConfig() texas.TableConfig {
+	return texas.TableConfig{
+		SmallBlind:    g.SmallBlind,
+		BigBlind:      g.BigBlind,
+		Ante:          g.Ante,
+		StartingStack: g.StartingStack,
+		MinBuyIn:      g.MinBuyIn,
+		MaxBuyIn:      g.MaxBuyIn,
+		MinPlayers:    g.MinPlayers,
+		MaxPlayers:    g.PlayerCap,
+		Structure:     texas.BettingStructure(g.Structure),
+		ActionTime:    g.ActionTime,
+		TimeBank:      g.TimeBank,
+		BuyIn:         g.BuyIn,
+		LevelMinutes:  g.LevelMinutes,
+	}
+}
+
+// Admits returns true if the invite code lets a player join the table.
+func (g *Game) Admits(code string) bool {
+	return !g.Private || (g.InviteCode != "" && code == g.InviteCode)
+}
+
+// GenerateInviteCode sets a new random invite code, the old one stops working.
+func (g *Game) GenerateInviteCode() error {
+	code := make([]byte, inviteCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(inviteAlphabet))))
+		if err != nil {
+			return err
+		}
+
+		code[i] = inviteAlphabet[n.Int64()]
+	}
+
+	g.InviteCode = string(code)
+	return nil
+}
+
+// IsFull returns true if every seat at the table is taken.
+func (g *Game) IsFull() bool {
+	return len(g.Players) >= g.PlayerCap
 }
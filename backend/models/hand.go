@@ -0,0 +1,327 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/TypicalAM/gopoker/texas"
+	"github.com/chehsunliu/poker"
+	"gorm.io/gorm"
+)
+
+// Hand is a recorded hand of poker.
+type Hand struct {
+	gorm.Model
+	GameUUID   string `gorm:"index"`
+	Number     int
+	Structure  string
+	TableSize  int
+	SmallBlind int
+	BigBlind   int
+	Ante       int
+	Dealer     int
+	Flop       string
+	Turn       string
+	River      string
+	Seats      []HandSeat
+	Actions    []HandAction
+	Pots       []HandPot
+
+	// The seeds the deck was shuffled with, the player seeds are stored as JSON
+	Commitment  string
+	ServerSeed  string
+	PlayerSeeds string
+}
+
+// HandSeat is a player dealt into a recorded hand.
+type HandSeat struct {
+	gorm.Model
+	HandID    uint `gorm:"index"`
+	UserID    uint `gorm:"index"`
+	Seat      int
+	Username  string
+	Stack     int
+	HoleCards string
+	Shown     bool
+	Won       int
+	Rank      string
+}
+
+// HandAction is a single action of a recorded hand.
+type HandAction struct {
+	gorm.Model
+	HandID   uint `gorm:"index"`
+	Sequence int
+	Round    string
+	Username string
+	Action   string
+	Amount   int
+	Bet      int
+}
+
+// HandPot is a pot of a recorded hand and the players who won it.
+type HandPot struct {
+	gorm.Model
+	HandID  uint `gorm:"index"`
+	Amount  int
+	Winners string
+}
+
+// NewHand creates the hand model from the engine history, the user IDs are looked up by username.
+func NewHand(gameUUID string, history texas.HandHistory, userIDs map[string]uint) Hand {
+	hand := Hand{
+		GameUUID:   gameUUID,
+		Number:     history.Number,
+		Structure:  string(history.Structure),
+		TableSize:  history.MaxPlayers,
+		SmallBlind: history.SmallBlind,
+		BigBlind:   history.BigBlind,
+		Ante:       history.Ante,
+		Dealer:     history.Dealer,
+		Commitment: history.Seeds.Commitment,
+		ServerSeed: history.Seeds.ServerSeed,
+	}
+
+	if playerSeeds, err := json.Marshal(history.Seeds.PlayerSeeds); err == nil {
+		hand.PlayerSeeds = string(playerSeeds)
+	}
+
+	if len(history.Board) >= 3 {
+		hand.Flop = cardsToString(history.Board[:3])
+	}
+
+	if len(history.Board) >= 4 {
+		hand.Turn = history.Board[3].String()
+	}
+
+	if len(history.Board) == 5 {
+		hand.River = history.Board[4].String()
+	}
+
+	for _, seat := range history.Seats {
+		handSeat := HandSeat{
+			UserID:    userIDs[seat.Name],
+			Seat:      seat.Seat,
+			Username:  seat.Name,
+			Stack:     seat.Stack,
+			HoleCards: cardsToString(seat.HoleCards),
+			Shown:     seat.Shown,
+		}
+
+		for _, winner := range history.Winners {
+			if winner.Name == seat.Name {
+				handSeat.Won = winner.Amount
+				handSeat.Rank = winner.Rank
+			}
+		}
+
+		hand.Seats = append(hand.Seats, handSeat)
+	}
+
+	for i, action := range history.Actions {
+		hand.Actions = append(hand.Actions, HandAction{
+			Sequence: i,
+			Round:    string(action.Round),
+			Username: action.Name,
+			Action:   string(action.Action),
+			Amount:   action.Amount,
+			Bet:      action.Bet,
+		})
+	}
+
+	for _, pot := range history.Pots {
+		hand.Pots = append(hand.Pots, HandPot{
+			Amount:  pot.Amount,
+			Winners: strings.Join(pot.Winners, ","),
+		})
+	}
+
+	return hand
+}
+
+// SafeHand is a safe recorded hand representation.
+type SafeHand struct {
+	ID         uint             `json:"id"`
+	GameUUID   string           `json:"game_uuid"`
+	Number     int              `json:"number"`
+	Structure  string           `json:"structure"`
+	TableSize  int              `json:"table_size"`
+	SmallBlind int              `json:"small_blind"`
+	BigBlind   int              `json:"big_blind"`
+	Ante       int              `json:"ante"`
+	Dealer     int              `json:"dealer"`
+	Board      []string         `json:"board"`
+	Seats      []SafeHandSeat   `json:"seats,omitempty"`
+	Actions    []SafeHandAction `json:"actions,omitempty"`
+	Pots       []SafeHandPot    `json:"pots,omitempty"`
+	PlayedAt   time.Time        `json:"played_at"`
+
+	// The shuffle the hand was dealt from, the server seed stays secret
+	Commitment  string             `json:"commitment,omitempty"`
+	PlayerSeeds []texas.PlayerSeed `json:"player_seeds,omitempty"`
+}
+
+// SafeHandSeat is a safe hand seat representation.
+type SafeHandSeat struct {
+	Seat      int      `json:"seat"`
+	Username  string   `json:"username"`
+	Stack     int      `json:"stack"`
+	HoleCards []string `json:"hole_cards"`
+	Shown     bool     `json:"shown"`
+	Won       int      `json:"won"`
+	Rank      string   `json:"rank,omitempty"`
+}
+
+// SafeHandAction is a safe hand action representation.
+type SafeHandAction struct {
+	Round    string `json:"round"`
+	Username string `json:"username"`
+	Action   string `json:"action"`
+	Amount   int    `json:"amount"`
+	Bet      int    `json:"bet"`
+}
+
+// SafeHandPot is a safe hand pot representation.
+type SafeHandPot struct {
+	Amount  int      `json:"amount"`
+	Winners []string `json:"winners"`
+}
+
+// Sanitize returns a safe hand representation for the user, the hole cards of
+// the other players are hidden unless they were shown at the showdown. The server
+// seed is left out, it would rebuild the deck and the mucked hole cards.
+func (h *Hand) Sanitize(userID uint) SafeHand {
+	safe := SafeHand{
+		ID:         h.ID,
+		GameUUID:   h.GameUUID,
+		Number:     h.Number,
+		Structure:  h.Structure,
+		TableSize:  h.TableSize,
+		SmallBlind: h.SmallBlind,
+		BigBlind:   h.BigBlind,
+		Ante:       h.Ante,
+		Dealer:     h.Dealer,
+		Board:      h.Board(),
+		PlayedAt:   h.CreatedAt,
+	}
+
+	seeds := h.Seeds()
+	safe.Commitment = seeds.Commitment
+	safe.PlayerSeeds = seeds.PlayerSeeds
+
+	for _, seat := range h.Seats {
+		holeCards := []string{}
+		if seat.UserID == userID || seat.Shown {
+			holeCards = splitCards(seat.HoleCards)
+		}
+
+		safe.Seats = append(safe.Seats, SafeHandSeat{
+			Seat:      seat.Seat,
+			Username:  seat.Username,
+			Stack:     seat.Stack,
+			HoleCards: holeCards,
+			Shown:     seat.Shown,
+			Won:       seat.Won,
+			Rank:      seat.Rank,
+		})
+	}
+
+	for _, action := range h.Actions {
+		safe.Actions = append(safe.Actions, SafeHandAction{
+			Round:    action.Round,
+			Username: action.Username,
+			Action:   action.Action,
+			Amount:   action.Amount,
+			Bet:      action.Bet,
+		})
+	}
+
+	for _, pot := range h.Pots {
+		safe.Pots = append(safe.Pots, SafeHandPot{
+			Amount:  pot.Amount,
+			Winners: splitWinners(pot.Winners),
+		})
+	}
+
+	return safe
+}
+
+// Seeds returns the seeds the deck of the hand was shuffled with.
+func (h *Hand) Seeds() texas.ShuffleSeeds {
+	seeds := texas.ShuffleSeeds{
+		Commitment:  h.Commitment,
+		ServerSeed:  h.ServerSeed,
+		PlayerSeeds: []texas.PlayerSeed{},
+	}
+
+	if h.PlayerSeeds != "" {
+		if err := json.Unmarshal([]byte(h.PlayerSeeds), &seeds.PlayerSeeds); err != nil {
+			seeds.PlayerSeeds = []texas.PlayerSeed{}
+		}
+	}
+
+	return seeds
+}
+
+// Verify recomputes the deck from the seeds and checks every dealt card against it.
+func (h *Hand) Verify() ([]poker.Card, error) {
+	holeCards := make(map[string][]poker.Card, len(h.Seats))
+	for _, seat := range h.Seats {
+		cards, err := texas.ParseCards(seat.HoleCards)
+		if err != nil {
+			return nil, err
+		}
+
+		holeCards[seat.Username] = cards
+	}
+
+	board, err := texas.ParseCards(strings.Join(h.Board(), " "))
+	if err != nil {
+		return nil, err
+	}
+
+	return texas.VerifyDeal(h.Seeds(), holeCards, board)
+}
+
+// Board returns the community cards of the hand.
+func (h *Hand) Board() []string {
+	board := splitCards(h.Flop)
+	if h.Turn != "" {
+		board = append(board, h.Turn)
+	}
+
+	if h.River != "" {
+		board = append(board, h.River)
+	}
+
+	return board
+}
+
+// cardsToString joins the cards with spaces.
+func cardsToString(cards []poker.Card) string {
+	names := make([]string, len(cards))
+	for i, card := range cards {
+		names[i] = card.String()
+	}
+
+	return strings.Join(names, " ")
+}
+
+// splitCards splits the cards joined by cardsToString.
+func splitCards(cards string) []string {
+	if cards == "" {
+		return []string{}
+	}
+
+	return strings.Split(cards, " ")
+}
+
+// splitWinners splits the winners of a pot.
+func splitWinners(winners string) []string {
+	if winners == "" {
+		return []string{}
+	}
+
+	return strings.Split(winners, ",")
+}
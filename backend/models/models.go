@@ -41,7 +41,8 @@ func delOrphan(db *gorm.DB) {
 
 // Migrate migrates the database.
 func Migrate(db *gorm.DB) error {
-	if err := db.AutoMigrate(&Game{}, &User{}, &Session{}, &Profile{}); err != nil {
+	if err := db.AutoMigrate(&Game{}, &User{}, &Session{}, &Profile{}, &Wallet{}, &LedgerEntry{},
+		&Hand{}, &HandSeat{}, &HandAction{}, &HandPot{}); err != nil {
 		return err
 	}
 
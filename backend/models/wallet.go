@@ -0,0 +1,145 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StartingBalance is the amount of chips a new wallet gets.
+var StartingBalance = 1000
+
+// InsufficientFundsErr is returned when a wallet can't cover a transaction.
+var InsufficientFundsErr = errors.New("insufficient funds")
+
+// LedgerType is the reason of a wallet balance change.
+type LedgerType string
+
+const (
+	LedgerBuyIn      LedgerType = "buy-in"
+	LedgerTopUp      LedgerType = "top-up"
+	LedgerCashOut    LedgerType = "cash-out"
+	LedgerWinnings   LedgerType = "winnings"
+	LedgerRake       LedgerType = "rake"
+	LedgerAdjustment LedgerType = "adjustment"
+)
+
+// Wallet holds the chips of a user.
+type Wallet struct {
+	gorm.Model
+	UserID  uint `gorm:"unique"`
+	Balance int
+	Entries []LedgerEntry
+}
+
+// LedgerEntry is a single change of a wallet balance, entries are never updated.
+type LedgerEntry struct {
+	gorm.Model
+	WalletID uint `gorm:"index"`
+	Type     LedgerType
+	Amount   int
+	Balance  int
+	GameUUID string
+}
+
+// SafeLedgerEntry is a safe ledger entry representation.
+type SafeLedgerEntry struct {
+	Type      LedgerType `json:"type"`
+	Amount    int        `json:"amount"`
+	Balance   int        `json:"balance"`
+	GameUUID  string     `json:"game_uuid,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Sanitize returns a safe ledger entry representation.
+func (e *LedgerEntry) Sanitize() SafeLedgerEntry {
+	return SafeLedgerEntry{
+		Type:      e.Type,
+		Amount:    e.Amount,
+		Balance:   e.Balance,
+		GameUUID:  e.GameUUID,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+// GetWallet returns the wallet of the user, creating it if the user doesn't have one yet.
+func GetWallet(db *gorm.DB, userID uint) (*Wallet, error) {
+	var wallet *Wallet
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		wallet, err = lockWallet(tx, userID)
+		return err
+	})
+
+	return wallet, err
+}
+
+// Transact changes the balance of the user's wallet by the amount and records the change
+// in the ledger. Negative amounts are debits and fail if the balance is too low.
+func Transact(db *gorm.DB, userID uint, entryType LedgerType, amount int, gameUUID string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		wallet, err := lockWallet(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		if wallet.Balance+amount < 0 {
+			return InsufficientFundsErr
+		}
+
+		return addEntry(tx, wallet, entryType, amount, gameUUID)
+	})
+}
+
+// lockWallet locks the wallet row for the rest of the transaction. A missing wallet is created
+// with the starting balance, when two transactions create it at once only one insert goes through.
+func lockWallet(tx *gorm.DB, userID uint) (*Wallet, error) {
+	var wallet Wallet
+	res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", userID).First(&wallet)
+	if res.Error == nil {
+		return &wallet, nil
+	}
+
+	if !errors.Is(res.Error, gorm.ErrRecordNotFound) {
+		return nil, res.Error
+	}
+
+	wallet = Wallet{UserID: userID}
+	if res = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&wallet); res.Error != nil {
+		return nil, res.Error
+	}
+
+	created := res.RowsAffected == 1
+	wallet = Wallet{}
+	if res = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", userID).First(&wallet); res.Error != nil {
+		return nil, res.Error
+	}
+
+	if created {
+		if err := addEntry(tx, &wallet, LedgerAdjustment, StartingBalance, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return &wallet, nil
+}
+
+// addEntry updates the balance and appends the ledger entry.
+func addEntry(tx *gorm.DB, wallet *Wallet, entryType LedgerType, amount int, gameUUID string) error {
+	wallet.Balance += amount
+	if res := tx.Model(wallet).Update("balance", wallet.Balance); res.Error != nil {
+		return res.Error
+	}
+
+	entry := LedgerEntry{
+		WalletID: wallet.ID,
+		Type:     entryType,
+		Amount:   amount,
+		Balance:  wallet.Balance,
+		GameUUID: gameUUID,
+	}
+
+	return tx.Create(&entry).Error
+}
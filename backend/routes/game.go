@@ -45,6 +45,33 @@ func (con controller) Game(c *gin.Context) {
 	con.gameSrv.Connect(conn, game, user)
 }
 
+// Watch is the websocket connection of a spectator
+func (con controller) Watch(c *gin.Context) {
+	user, err := con.getUser(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user"})
+		return
+	}
+
+	var game models.Game
+	res := con.db.Where("uuid = ? AND private = ?", c.Param("id"), false).First(&game)
+	if res.Error != nil || !con.gameSrv.IsRunning(game.UUID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+		return
+	}
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "can't upgrade to websocket"})
+		return
+	}
+
+	if err = con.gameSrv.Watch(conn, c.Param("id"), user); err != nil {
+		log.Printf("Cannot watch the game: %s", err)
+		conn.Close()
+	}
+}
+
 // ensureCorrectGame checks if the user is in the game and the game exists
 func (con controller) ensureCorrectGame(db *gorm.DB, user *models.User, c *gin.Context) (*models.Game, error) {
 	log.Println("Adding a player because of the link")
@@ -58,9 +85,25 @@ func (con controller) ensureCorrectGame(db *gorm.DB, user *models.User, c *gin.C
 	}
 
 	gameIDInterface := session.Get(models.GameIDKey)
-	if gameIDInterface == nil || gameIDInterface.(string) != gameID {
-		// Can we add a player?
-		if game.Playing || len(game.Players) == con.config.GamePlayerCap {
+	sessionGame, _ := gameIDInterface.(string)
+
+	// A seated player can always connect, the tournament director might have moved them here
+	seated := false
+	for _, player := range game.Players {
+		if player.ID == user.ID {
+			seated = true
+		}
+	}
+
+	if !seated {
+		// Can we add a player? Private tables need the invite code, the session isn't trusted since
+		// a kicked player or the holder of a revoked code keeps it
+		if !con.seatFree(&game) || !game.Admits(c.Query("code")) {
+			if sessionGame == gameID {
+				session.Delete(models.GameIDKey)
+				session.Save()
+			}
+
 			return nil, incorrectGameErr
 		}
 
@@ -72,7 +115,9 @@ func (con controller) ensureCorrectGame(db *gorm.DB, user *models.User, c *gin.C
 			})
 			return nil, res.Error
 		}
+	}
 
+	if sessionGame != gameID {
 		session.Set(models.GameIDKey, game.UUID)
 		if err := session.Save(); err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{
@@ -80,15 +125,7 @@ func (con controller) ensureCorrectGame(db *gorm.DB, user *models.User, c *gin.C
 			})
 			return nil, err
 		}
-
-		return &game, nil
-	}
-
-	for _, player := range game.Players {
-		if player.ID == user.ID {
-			return &game, nil
-		}
 	}
 
-	return nil, incorrectGameErr
+	return &game, nil
 }
@@ -0,0 +1,154 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/TypicalAM/gopoker/models"
+	"github.com/TypicalAM/gopoker/services/export"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Hands lists the hands the user was dealt into, newest first.
+func (con controller) Hands(c *gin.Context) {
+	user, err := con.getUser(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user"})
+		return
+	}
+
+	page, limit, err := getPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userHands := con.db.Model(&models.Hand{}).
+		Joins("JOIN hand_seats ON hand_seats.hand_id = hands.id AND hand_seats.deleted_at IS NULL").
+		Where("hand_seats.user_id = ?", user.ID).
+		Session(&gorm.Session{})
+
+	var total int64
+	if res := userHands.Count(&total); res.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "There was an error finding your hands. Please try again later."})
+		return
+	}
+
+	var hands []models.Hand
+	res := userHands.Preload("Seats").Order("hands.id desc").Offset((page - 1) * limit).Limit(limit).Find(&hands)
+	if res.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "There was an error finding your hands. Please try again later."})
+		return
+	}
+
+	safeHands := make([]models.SafeHand, len(hands))
+	for i := range hands {
+		safeHands[i] = hands[i].Sanitize(user.ID)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"hands": safeHands,
+		"page":  page,
+		"limit": limit,
+		"total": total,
+	})
+}
+
+// Hand fetches a full hand the user was dealt into.
+func (con controller) Hand(c *gin.Context) {
+	user, err := con.getUser(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user"})
+		return
+	}
+
+	hand, err := con.getHand(c.Param("id"), user)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "hand not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"hand": hand.Sanitize(user.ID)})
+}
+
+// ExportHand downloads a hand the user was dealt into as a hand history file.
+func (con controller) ExportHand(c *gin.Context) {
+	user, err := con.getUser(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user"})
+		return
+	}
+
+	format, err := export.ParseFormat(c.DefaultQuery("format", string(export.PokerStars)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hand, err := con.getHand(c.Param("id"), user)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "hand not found"})
+		return
+	}
+
+	data, err := export.Hand(format, hand.Sanitize(user.ID), user.Username)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "There was an error exporting the hand. Please try again later."})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"hand-%d%s\"", hand.ID, format.Extension()))
+	c.Data(http.StatusOK, format.ContentType(), data)
+}
+
+// VerifyHand recomputes the deck of a hand the user was dealt into from its seeds
+// and checks the commitment and the dealt cards against it. Only the result is
+// returned, the deck would give away the hole cards nobody has shown.
+func (con controller) VerifyHand(c *gin.Context) {
+	user, err := con.getUser(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user"})
+		return
+	}
+
+	hand, err := con.getHand(c.Param("id"), user)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "hand not found"})
+		return
+	}
+
+	if hand.ServerSeed == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "The shuffle of this hand wasn't recorded"})
+		return
+	}
+
+	response := gin.H{"verified": true}
+	if _, err := hand.Verify(); err != nil {
+		response["verified"] = false
+		response["error"] = err.Error()
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// getHand loads a full hand if the user was dealt into it.
+func (con controller) getHand(id string, user *models.User) (*models.Hand, error) {
+	var hand models.Hand
+	res := con.db.Preload("Seats", func(db *gorm.DB) *gorm.DB {
+		return db.Order("seat")
+	}).Preload("Actions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("sequence")
+	}).Preload("Pots").Where("id = ?", id).First(&hand)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	for _, seat := range hand.Seats {
+		if seat.UserID == user.ID {
+			return &hand, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
@@ -0,0 +1,173 @@
+package routes
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/TypicalAM/gopoker/models"
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+)
+
+// KickData is the data sent to the kick route
+type KickData struct {
+	Username string `json:"username" binding:"required"`
+}
+
+// CreatePrivate creates a private table with the chosen stakes and returns its invite code
+func (con controller) CreatePrivate(c *gin.Context) {
+	user, err := con.getUser(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user"})
+		return
+	}
+
+	var data QueueData
+	if err := c.ShouldBindJSON(&data); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
+		return
+	}
+
+	tableConfig := con.tableConfig(data)
+	if err := tableConfig.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid table stakes"})
+		return
+	}
+
+	game, err := con.createGame(user, tableConfig, true)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "There was an error creating a new game. Please try again later.",
+		})
+		return
+	}
+
+	session := sessions.Default(c)
+	session.Set(models.GameIDKey, game.UUID)
+	if err := session.Save(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "There was an error saving your session. Please try again later.",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"uuid":        game.UUID,
+		"invite_code": game.InviteCode,
+		"link":        inviteLink(game.InviteCode),
+	})
+}
+
+// NewInvite replaces the invite code of a private table, the old code stops working
+func (con controller) NewInvite(c *gin.Context) {
+	game, ok := con.ownedGame(c)
+	if !ok {
+		return
+	}
+
+	if err := game.GenerateInviteCode(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "There was an error creating the invite code. Please try again later."})
+		return
+	}
+
+	if res := con.db.Model(game).Update("invite_code", game.InviteCode); res.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "There was an error saving the invite code. Please try again later."})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"invite_code": game.InviteCode,
+		"link":        inviteLink(game.InviteCode),
+	})
+}
+
+// RevokeInvite revokes the invite code of a private table
+func (con controller) RevokeInvite(c *gin.Context) {
+	game, ok := con.ownedGame(c)
+	if !ok {
+		return
+	}
+
+	// The players who got in with the code stay seated, the others need a new code even if their session points here
+	if res := con.db.Model(game).Update("invite_code", ""); res.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "There was an error revoking the invite code. Please try again later."})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "The invite code has been revoked"})
+}
+
+// Kick removes a player from a private table before the first hand is dealt
+func (con controller) Kick(c *gin.Context) {
+	game, ok := con.ownedGame(c)
+	if !ok {
+		return
+	}
+
+	var data KickData
+	if err := c.ShouldBindJSON(&data); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
+		return
+	}
+
+	if game.Playing {
+		c.JSON(http.StatusConflict, gin.H{"error": "The game has already started"})
+		return
+	}
+
+	for _, player := range game.Players {
+		if player.Username != data.Username {
+			continue
+		}
+
+		if player.ID == game.OwnerID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot kick yourself"})
+			return
+		}
+
+		if err := con.gameSrv.Kick(game.UUID, player.Username); err != nil {
+			c.JSON(http.StatusConflict, gin.H{"error": "The game has already started"})
+			return
+		}
+
+		// Unseating the player is enough, their session alone doesn't let them back in
+		if err := con.db.Model(game).Association("Players").Delete(&player); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "There was an error kicking the player. Please try again later."})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "The player has been kicked"})
+		return
+	}
+
+	c.JSON(http.StatusNotFound, gin.H{"error": "player not found"})
+}
+
+// ownedGame loads the private game from the url if the user owns it, it writes the error response otherwise
+func (con controller) ownedGame(c *gin.Context) (*models.Game, bool) {
+	user, err := con.getUser(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user"})
+		return nil, false
+	}
+
+	var game models.Game
+	res := con.db.Preload("Players").Where("uuid = ? AND private = ?", c.Param("id"), true).First(&game)
+	if res.Error != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+		return nil, false
+	}
+
+	if game.OwnerID != user.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only the owner can manage the table"})
+		return nil, false
+	}
+
+	return &game, true
+}
+
+// inviteLink is the frontend link which joins the private table
+func inviteLink(code string) string {
+	return "/game/queue?code=" + code
+}
@@ -1,40 +1,66 @@
 package routes
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"sort"
 
 	"github.com/TypicalAM/gopoker/middleware"
 	"github.com/TypicalAM/gopoker/models"
+	"github.com/TypicalAM/gopoker/texas"
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
+// The game modes the queue finds tables for
+const (
+	cashGame   = "cash"
+	sitAndGo   = "sit-and-go"
+	multiTable = "multi-table"
+)
+
+// QueueData is the data that can be sent to the queue route to pick the table stakes,
+// the missing values are taken from the config. The invite code joins a private table,
+// the sit-and-go mode registers for a tournament with the stakes from the config and the
+// multi-table mode registers for the next multi-table tournament at the same stakes.
+type QueueData struct {
+	Mode          string `json:"mode,omitempty"`
+	SmallBlind    int    `json:"small_blind,omitempty"`
+	BigBlind      int    `json:"big_blind,omitempty"`
+	Ante          int    `json:"ante,omitempty"`
+	StartingStack int    `json:"starting_stack,omitempty"`
+	MinBuyIn      int    `json:"min_buy_in,omitempty"`
+	MaxBuyIn      int    `json:"max_buy_in,omitempty"`
+	MinPlayers    int    `json:"min_players,omitempty"`
+	PlayerCap     int    `json:"player_cap,omitempty"`
+	Structure     string `json:"structure,omitempty"`
+	InviteCode    string `json:"invite_code,omitempty"`
+}
+
 // Queue allows the user to join a game queue
 func (con controller) Queue(c *gin.Context) {
-	var games []models.Game
-	res := con.db.Model(&models.Game{}).Preload("Players").Where("playing = ?", false).Find(&games)
-	if res.Error != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "There was an error finding games. Please try again later.",
-		})
+	var data QueueData
+	if err := c.ShouldBindJSON(&data); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
 		return
 	}
 
 	var user models.User
-	res = con.db.Where("id = ?", c.MustGet(middleware.UserIDKey)).First(&user)
+	res := con.db.Where("id = ?", c.MustGet(middleware.UserIDKey)).First(&user)
 	if res.Error != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "There was an error finding your user. Please try again later.",
 		})
+		return
 	}
 
 	session := sessions.Default(c)
 	gameIDInterface := session.Get(models.GameIDKey)
 	if gameID, ok := gameIDInterface.(string); ok {
 		var game models.Game
-		res = con.db.Where("uuid = ?", gameID).First(&game)
+		res = con.db.Preload("Players").Where("uuid = ?", gameID).First(&game)
 		if res.Error != nil {
 			session.Set(models.GameIDKey, nil)
 		} else {
@@ -57,8 +83,66 @@ func (con controller) Queue(c *gin.Context) {
 		}
 	}
 
-	if len(games) == 0 {
-		con.createNewGame(c, &user)
+	// A tournament director might have seated the user at a table they haven't visited yet
+	if user.GameID != nil {
+		var game models.Game
+		res = con.db.Where("id = ? AND playing = ?", *user.GameID, true).First(&game)
+		if res.Error == nil {
+			c.JSON(http.StatusOK, gin.H{
+				"message": "You are already in a game.",
+				"uuid":    game.UUID,
+			})
+			return
+		}
+	}
+
+	// The invite code takes the user straight to the private table
+	if data.InviteCode != "" {
+		var game models.Game
+		res = con.db.Preload("Players").Where("private = ? AND invite_code = ?", true, data.InviteCode).First(&game)
+		if res.Error != nil || !con.seatFree(&game) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invalid invite code"})
+			return
+		}
+
+		con.joinGame(c, &user, &game)
+		return
+	}
+
+	if data.Mode != "" && data.Mode != cashGame && data.Mode != sitAndGo && data.Mode != multiTable {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game mode"})
+		return
+	}
+
+	if data.Mode == multiTable {
+		con.enterTournament(c, &user)
+		return
+	}
+
+	tableConfig := con.tableConfig(data)
+	if err := tableConfig.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid table stakes"})
+		return
+	}
+
+	var games []models.Game
+	res = con.db.Model(&models.Game{}).Preload("Players").Where("(playing = ? OR buy_in = ?) AND private = ?", false, 0, false).Where(map[string]interface{}{
+		"small_blind":    tableConfig.SmallBlind,
+		"big_blind":      tableConfig.BigBlind,
+		"ante":           tableConfig.Ante,
+		"starting_stack": tableConfig.StartingStack,
+		"min_buy_in":     tableConfig.MinBuyIn,
+		"max_buy_in":     tableConfig.MaxBuyIn,
+		"min_players":    tableConfig.MinPlayers,
+		"player_cap":     tableConfig.MaxPlayers,
+		"structure":      string(tableConfig.Structure),
+		"buy_in":         tableConfig.BuyIn,
+		"level_minutes":  tableConfig.LevelMinutes,
+	}).Find(&games)
+	if res.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "There was an error finding games. Please try again later.",
+		})
 		return
 	}
 
@@ -66,50 +150,175 @@ func (con controller) Queue(c *gin.Context) {
 		return len(games[i].Players) < len(games[j].Players)
 	})
 
-	for i, game := range games {
+	for i := range games {
 		// Check if we didn't fully fill the game in the meantime
-		if game.Playing || len(game.Players) == con.config.GamePlayerCap {
+		if !con.seatFree(&games[i]) {
 			continue
 		}
 
-		// Add the user to the game
-		games[i].Players = append(games[i].Players, user)
-		res = con.db.Save(&games[i])
-		if res.Error != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "There was an error adding you to the game. Please try again later.",
-			})
-			return
-		}
+		con.joinGame(c, &user, &games[i])
+		return
+	}
 
-		session := sessions.Default(c)
-		session.Set(models.GameIDKey, game.UUID)
-		if err := session.Save(); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "There was an error saving your session. Please try again later.",
-			})
-			return
-		}
+	// Every table with these stakes is full
+	con.createNewGame(c, &user, tableConfig)
+}
 
-		c.JSON(http.StatusOK, gin.H{
-			"uuid": game.UUID,
+// joinGame adds the user to the game and remembers the game in the session
+func (con controller) joinGame(c *gin.Context, user *models.User, game *models.Game) {
+	game.Players = append(game.Players, *user)
+	if res := con.db.Save(game); res.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "There was an error adding you to the game. Please try again later.",
 		})
+		return
+	}
 
+	session := sessions.Default(c)
+	session.Set(models.GameIDKey, game.UUID)
+	if err := session.Save(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "There was an error saving your session. Please try again later.",
+		})
 		return
 	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"uuid": game.UUID,
+	})
 }
 
-// createNewGame creates a new game and adds the user to it
-func (con controller) createNewGame(c *gin.Context, user *models.User) {
-	newGameUUID := uuid.New().String()
-	game := models.Game{
-		Playing: false,
-		UUID:    newGameUUID,
-		Players: []models.User{*user},
+// enterTournament registers the user for the next multi-table tournament, the users who
+// registered earlier find their table by queueing again once the tournament has started
+func (con controller) enterTournament(c *gin.Context, user *models.User) {
+	tableConfig := con.sitAndGoConfig()
+	tableConfig.MaxPlayers = con.config.MultiTablePlayers
+	started, err := con.gameSrv.EnterTournament(user, tableConfig, con.config.MultiTableEntrants)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "There was an error starting the tournament. Please register again.",
+		})
+		return
+	}
+
+	if !started {
+		c.JSON(http.StatusOK, gin.H{
+			"message":    "You are registered for the next tournament.",
+			"registered": con.gameSrv.Registered(),
+			"entrants":   con.config.MultiTableEntrants,
+		})
+		return
 	}
 
-	res := con.db.Model(&models.Game{}).Preload("Players").Create(&game)
+	var game models.Game
+	res := con.db.Joins("JOIN users ON users.game_id = games.id").Where("users.id = ?", user.ID).First(&game)
 	if res.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "There was an error finding your table. Please try again later.",
+		})
+		return
+	}
+
+	session := sessions.Default(c)
+	session.Set(models.GameIDKey, game.UUID)
+	if err := session.Save(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "There was an error saving your session. Please try again later.",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"uuid": game.UUID,
+	})
+}
+
+// tableConfig creates the table config from the queue data and the config defaults
+func (con controller) tableConfig(data QueueData) texas.TableConfig {
+	if data.Mode == sitAndGo {
+		return con.sitAndGoConfig()
+	}
+
+	tableConfig := texas.TableConfig{
+		SmallBlind:    con.config.GameSmallBlind,
+		BigBlind:      con.config.GameBigBlind,
+		Ante:          con.config.GameAnte,
+		StartingStack: con.config.GameStartingStack,
+		MinBuyIn:      con.config.GameMinBuyIn,
+		MaxBuyIn:      con.config.GameMaxBuyIn,
+		MinPlayers:    con.config.GameMinPlayers,
+		MaxPlayers:    con.config.GamePlayerCap,
+		Structure:     texas.NoLimit,
+		ActionTime:    con.config.GameActionTime,
+		TimeBank:      con.config.GameTimeBank,
+	}
+
+	if data.SmallBlind != 0 {
+		tableConfig.SmallBlind = data.SmallBlind
+	}
+
+	if data.BigBlind != 0 {
+		tableConfig.BigBlind = data.BigBlind
+	}
+
+	if data.Ante != 0 {
+		tableConfig.Ante = data.Ante
+	}
+
+	if data.StartingStack != 0 {
+		tableConfig.StartingStack = data.StartingStack
+	}
+
+	if data.MinBuyIn != 0 {
+		tableConfig.MinBuyIn = data.MinBuyIn
+	}
+
+	if data.MaxBuyIn != 0 {
+		tableConfig.MaxBuyIn = data.MaxBuyIn
+	}
+
+	if data.PlayerCap != 0 {
+		tableConfig.MaxPlayers = data.PlayerCap
+	}
+
+	// A smaller table starts with fewer players unless the minimum is picked as well, two seats make a heads-up match
+	if data.MinPlayers != 0 {
+		tableConfig.MinPlayers = data.MinPlayers
+	} else if tableConfig.MinPlayers > tableConfig.MaxPlayers {
+		tableConfig.MinPlayers = tableConfig.MaxPlayers
+	}
+
+	if data.Structure != "" {
+		tableConfig.Structure = texas.BettingStructure(data.Structure)
+	}
+
+	return tableConfig
+}
+
+// sitAndGoConfig returns the config of a sit-and-go, everyone starts with the same stack at the first blind level
+func (con controller) sitAndGoConfig() texas.TableConfig {
+	level := texas.BlindSchedule[0]
+	return texas.TableConfig{
+		SmallBlind:    level.SmallBlind,
+		BigBlind:      level.BigBlind,
+		Ante:          level.Ante,
+		StartingStack: con.config.SitAndGoStack,
+		MinBuyIn:      con.config.SitAndGoStack,
+		MaxBuyIn:      con.config.SitAndGoStack,
+		MinPlayers:    texas.MinimumPlayers,
+		MaxPlayers:    con.config.SitAndGoPlayers,
+		Structure:     texas.NoLimit,
+		ActionTime:    con.config.GameActionTime,
+		TimeBank:      con.config.GameTimeBank,
+		BuyIn:         con.config.SitAndGoBuyIn,
+		LevelMinutes:  con.config.SitAndGoLevelMinutes,
+	}
+}
+
+// createNewGame creates a new game with the given stakes and adds the user to it
+func (con controller) createNewGame(c *gin.Context, user *models.User, tableConfig texas.TableConfig) {
+	game, err := con.createGame(user, tableConfig, false)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "There was an error creating a new game. Please try again later.",
 		})
@@ -117,7 +326,7 @@ func (con controller) createNewGame(c *gin.Context, user *models.User) {
 	}
 
 	session := sessions.Default(c)
-	session.Set(models.GameIDKey, newGameUUID)
+	session.Set(models.GameIDKey, game.UUID)
 	if err := session.Save(); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "There was an error saving your session. Please try again later.",
@@ -129,3 +338,27 @@ func (con controller) createNewGame(c *gin.Context, user *models.User) {
 		"uuid": game.UUID,
 	})
 }
+
+// createGame saves a new game with the user as its first player, private games get an invite code
+func (con controller) createGame(user *models.User, tableConfig texas.TableConfig, private bool) (*models.Game, error) {
+	game := models.Game{
+		Playing: false,
+		UUID:    uuid.New().String(),
+		Players: []models.User{*user},
+		Private: private,
+		OwnerID: user.ID,
+	}
+	game.SetTableConfig(tableConfig)
+
+	if private {
+		if err := game.GenerateInviteCode(); err != nil {
+			return nil, err
+		}
+	}
+
+	if res := con.db.Model(&models.Game{}).Preload("Players").Create(&game); res.Error != nil {
+		return nil, res.Error
+	}
+
+	return &game, nil
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/TypicalAM/gopoker/models"
+	"github.com/TypicalAM/gopoker/services/bot"
 	"github.com/gin-gonic/gin"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -27,6 +28,12 @@ func (con controller) Register(c *gin.Context) {
 		return
 	}
 
+	// The bot names can't be taken by the users
+	if bot.IsBot(data.Username) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Username is reserved"})
+		return
+	}
+
 	if len(data.Password) < 8 {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Password must be at least 8 characters long"})
 		return
@@ -43,7 +43,7 @@ func New(db *gorm.DB, cfg *config.Config, uploader upload.Uploader) (*gin.Engine
 	router.Use(middleware.General())
 
 	// Create the game server and the controller
-	gameSrv := game.New(db)
+	gameSrv := game.New(db, cfg)
 	go gameSrv.Run()
 	controller := controller{
 		db:       db,
@@ -71,8 +71,19 @@ func New(db *gorm.DB, cfg *config.Config, uploader upload.Uploader) (*gin.Engine
 	auth.POST("/logout", controller.Logout)
 	auth.POST("/game/queue", controller.Queue)
 	auth.GET("/game/id/:id", controller.Game)
+	auth.GET("/game/watch/:id", controller.Watch)
+	auth.POST("/game/private", controller.CreatePrivate)
+	auth.POST("/game/private/:id/invite", controller.NewInvite)
+	auth.DELETE("/game/private/:id/invite", controller.RevokeInvite)
+	auth.POST("/game/private/:id/kick", controller.Kick)
 	auth.GET("/profile", controller.Profile)
 	auth.PUT("/profile", controller.ProfileUpdate)
+	auth.GET("/tables", controller.Tables)
+	auth.GET("/wallet", controller.Wallet)
+	auth.GET("/hands", controller.Hands)
+	auth.GET("/hands/:id", controller.Hand)
+	auth.GET("/hands/:id/export", controller.ExportHand)
+	auth.GET("/hands/:id/verify", controller.VerifyHand)
 
 	return router, nil
 }
@@ -78,6 +78,11 @@ func TestRegister(t *testing.T) {
 			body: `{"username":"test3","password":"test"}`,
 			code: http.StatusBadRequest,
 		},
+		{
+			name: "bot name",
+			body: `{"username":"Bot-ada","password":"testtest"}`,
+			code: http.StatusBadRequest,
+		},
 		{
 			name: "normal register",
 			body: `{"username":"test4","password":"testtest"}`,
@@ -286,6 +291,394 @@ func TestQueue(t *testing.T) {
 	}
 }
 
+func TestQueueStakes(t *testing.T) {
+	err, cookie := logInUser(`{"username":"user2","password":"testpass2"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tt := []struct {
+		name string
+		body string
+		code int
+	}{
+		{
+			name: "big blind smaller than the small blind",
+			body: `{"small_blind":10,"big_blind":5}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "unknown betting structure",
+			body: `{"structure":"spread-limit"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "unknown game mode",
+			body: `{"mode":"freeroll"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "higher stakes",
+			body: `{"small_blind":5,"big_blind":10,"starting_stack":1000,"min_buy_in":400,"max_buy_in":2000}`,
+			code: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/game/queue", bytes.NewBuffer([]byte(tc.body)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req.AddCookie(cookie)
+			rr := httptest.NewRecorder()
+			trouter.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.code {
+				t.Errorf("handler returned wrong status code: got %v want %v", status, tc.code)
+			}
+		})
+	}
+}
+
+func TestWallet(t *testing.T) {
+	err, cookie := logInUser(`{"username":"user1","password":"testpass1"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tt := []struct {
+		name  string
+		query string
+		code  int
+	}{
+		{
+			name:  "first page",
+			query: "",
+			code:  http.StatusOK,
+		},
+		{
+			name:  "invalid page",
+			query: "?page=0",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "page too large",
+			query: "?limit=1000",
+			code:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/api/wallet"+tc.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req.AddCookie(cookie)
+			rr := httptest.NewRecorder()
+			trouter.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.code {
+				t.Errorf("handler returned wrong status code: got %v want %v", status, tc.code)
+			}
+
+			if tc.code != http.StatusOK {
+				return
+			}
+
+			var walletResponse struct {
+				Balance int                      `json:"balance"`
+				Entries []models.SafeLedgerEntry `json:"entries"`
+			}
+
+			if err := json.NewDecoder(rr.Body).Decode(&walletResponse); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(walletResponse.Entries) == 0 {
+				t.Fatalf("expected the starting balance to be in the ledger")
+			}
+
+			if walletResponse.Balance != walletResponse.Entries[0].Balance {
+				t.Errorf("expected the balance %v to match the last entry %v", walletResponse.Balance, walletResponse.Entries[0].Balance)
+			}
+		})
+	}
+}
+
+func TestHands(t *testing.T) {
+	err, cookie := logInUser(`{"username":"user1","password":"testpass1"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tt := []struct {
+		name string
+		url  string
+		code int
+	}{
+		{
+			name: "list hands",
+			url:  "/api/hands",
+			code: http.StatusOK,
+		},
+		{
+			name: "list hands invalid page",
+			url:  "/api/hands?page=-1",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "hand not found",
+			url:  "/api/hands/999999999",
+			code: http.StatusNotFound,
+		},
+		{
+			name: "export unknown format",
+			url:  "/api/hands/999999999/export?format=xml",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "export hand not found",
+			url:  "/api/hands/999999999/export?format=pokerstars",
+			code: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", tc.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req.AddCookie(cookie)
+			rr := httptest.NewRecorder()
+			trouter.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.code {
+				t.Errorf("handler returned wrong status code: got %v want %v", status, tc.code)
+			}
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	err, cookie := logInUser(`{"username":"user1","password":"testpass1"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest("GET", "/api/game/watch/not-a-game", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.AddCookie(cookie)
+	rr := httptest.NewRecorder()
+	trouter.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNotFound {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNotFound)
+	}
+}
+
+func TestPrivate(t *testing.T) {
+	err, ownerCookie := logInUser(`{"username":"user1","password":"testpass1"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err, guestCookie := logInUser(`{"username":"user2","password":"testpass2"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest("POST", "/api/game/private", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.AddCookie(ownerCookie)
+	rr := httptest.NewRecorder()
+	trouter.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+
+	var private struct {
+		UUID       string `json:"uuid"`
+		InviteCode string `json:"invite_code"`
+	}
+
+	if err := json.NewDecoder(rr.Body).Decode(&private); err != nil {
+		t.Fatal(err)
+	}
+
+	if private.InviteCode == "" {
+		t.Fatal("handler returned an empty invite code")
+	}
+
+	tt := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		code   int
+	}{
+		{
+			name:   "wrong invite code",
+			method: "POST",
+			url:    "/api/game/queue",
+			body:   `{"invite_code":"WRONGCODE"}`,
+			code:   http.StatusNotFound,
+		},
+		{
+			name:   "not the owner",
+			method: "POST",
+			url:    "/api/game/private/" + private.UUID + "/invite",
+			code:   http.StatusForbidden,
+		},
+		{
+			name:   "correct invite code",
+			method: "POST",
+			url:    "/api/game/queue",
+			body:   `{"invite_code":"` + private.InviteCode + `"}`,
+			code:   http.StatusOK,
+		},
+	}
+
+	var sessionCookies []*http.Cookie
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, tc.url, bytes.NewBuffer([]byte(tc.body)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req.AddCookie(guestCookie)
+			rr := httptest.NewRecorder()
+			trouter.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.code {
+				t.Errorf("handler returned wrong status code: got %v want %v", status, tc.code)
+			}
+
+			if rr.Code == http.StatusOK {
+				sessionCookies = rr.Result().Cookies()
+			}
+		})
+	}
+
+	var game models.Game
+	tdb.Model(&models.Game{}).Preload("Players").Where("uuid = ?", private.UUID).First(&game)
+	if len(game.Players) != 2 {
+		t.Errorf("private game has wrong number of players: got %v want %v", len(game.Players), 2)
+	}
+
+	req, err = http.NewRequest("POST", "/api/game/private/"+private.UUID+"/kick", bytes.NewBuffer([]byte(`{"username":"user2"}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.AddCookie(ownerCookie)
+	rr = httptest.NewRecorder()
+	trouter.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	// The session of the kicked player still points at the table, it doesn't let them back in
+	req, err = http.NewRequest("GET", "/api/game/id/"+private.UUID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, cookie := range sessionCookies {
+		req.AddCookie(cookie)
+	}
+
+	rr = httptest.NewRecorder()
+	trouter.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+}
+
+func TestTables(t *testing.T) {
+	err, cookie := logInUser(`{"username":"user1","password":"testpass1"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tt := []struct {
+		name string
+		url  string
+		code int
+	}{
+		{
+			name: "all tables",
+			url:  "/api/tables",
+			code: http.StatusOK,
+		},
+		{
+			name: "open tables with a free seat",
+			url:  "/api/tables?status=open&seat_free=true&small_blind=1&big_blind=2",
+			code: http.StatusOK,
+		},
+		{
+			name: "unknown status",
+			url:  "/api/tables?status=closed",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "invalid stakes",
+			url:  "/api/tables?big_blind=lots",
+			code: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", tc.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req.AddCookie(cookie)
+			rr := httptest.NewRecorder()
+			trouter.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.code {
+				t.Errorf("handler returned wrong status code: got %v want %v", status, tc.code)
+			}
+
+			if tc.code != http.StatusOK {
+				return
+			}
+
+			var resp struct {
+				Tables []TableListing `json:"tables"`
+			}
+
+			if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, table := range resp.Tables {
+				if table.Seated > table.MaxPlayers {
+					t.Errorf("table %s has more players than seats: %d > %d", table.UUID, table.Seated, table.MaxPlayers)
+				}
+			}
+		})
+	}
+}
+
 // teardown removes the test users from the database
 func teardown() error {
 	return tdb.Where("username = ?", "user1").Or("username = ?", "user2").Delete(&models.User{}).Error
@@ -0,0 +1,165 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/TypicalAM/gopoker/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// TableListing is a public table shown in the table browser
+type TableListing struct {
+	UUID         string  `json:"uuid"`
+	SmallBlind   int     `json:"small_blind"`
+	BigBlind     int     `json:"big_blind"`
+	Ante         int     `json:"ante"`
+	MinBuyIn     int     `json:"min_buy_in"`
+	MaxBuyIn     int     `json:"max_buy_in"`
+	Structure    string  `json:"structure"`
+	BuyIn        int     `json:"buy_in"`
+	Seated       int     `json:"seated"`
+	MinPlayers   int     `json:"min_players"`
+	MaxPlayers   int     `json:"max_players"`
+	Running      bool    `json:"running"`
+	SeatFree     bool    `json:"seat_free"`
+	Hands        int     `json:"hands"`
+	AveragePot   int     `json:"average_pot"`
+	HandsPerHour float64 `json:"hands_per_hour"`
+}
+
+// seatedPlayers counts the players assigned to a game
+const seatedPlayers = "(SELECT COUNT(*) FROM users WHERE users.game_id = games.id AND users.deleted_at IS NULL)"
+
+// Tables lists the public tables, they can be filtered by the status, the game mode, the stakes and the free seats.
+func (con controller) Tables(c *gin.Context) {
+	page, limit, err := getPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tables := con.db.Model(&models.Game{}).Where("private = ?", false)
+	switch c.Query("status") {
+	case "":
+	case "open":
+		tables = tables.Where("playing = ?", false)
+	case "running":
+		tables = tables.Where("playing = ?", true)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+		return
+	}
+
+	switch c.Query("mode") {
+	case "":
+	case cashGame:
+		tables = tables.Where("buy_in = ?", 0)
+	case sitAndGo:
+		tables = tables.Where("buy_in > ?", 0)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid mode"})
+		return
+	}
+
+	for _, column := range []string{"small_blind", "big_blind"} {
+		value := c.Query(column)
+		if value == "" {
+			continue
+		}
+
+		amount, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + column})
+			return
+		}
+
+		tables = tables.Where(column+" = ?", amount)
+	}
+
+	if structure := c.Query("structure"); structure != "" {
+		tables = tables.Where("structure = ?", structure)
+	}
+
+	if c.Query("seat_free") == "true" {
+		tables = tables.Where("(playing = ? OR buy_in = ?) AND "+seatedPlayers+" < player_cap", false, 0)
+	}
+
+	tables = tables.Session(&gorm.Session{})
+
+	var total int64
+	if res := tables.Count(&total); res.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "There was an error finding the tables. Please try again later."})
+		return
+	}
+
+	var games []models.Game
+	res := tables.Preload("Players").Order("id desc").Offset((page - 1) * limit).Limit(limit).Find(&games)
+	if res.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "There was an error finding the tables. Please try again later."})
+		return
+	}
+
+	listings := make([]TableListing, len(games))
+	for i := range games {
+		listings[i] = con.tableListing(&games[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tables": listings,
+		"page":   page,
+		"limit":  limit,
+		"total":  total,
+	})
+}
+
+// seatFree checks if the user can sit down at the game, a running cash table seats
+// the new players between the hands while a tournament can't be joined once it starts
+func (con controller) seatFree(game *models.Game) bool {
+	if !game.Playing {
+		return !game.IsFull()
+	}
+
+	if game.TableConfig().IsTournament() {
+		return false
+	}
+
+	stats, ok := con.gameSrv.Stats(game.UUID)
+	return ok && stats.Seated < game.PlayerCap
+}
+
+// tableListing combines the stored game with the live statistics of the table
+func (con controller) tableListing(game *models.Game) TableListing {
+	listing := TableListing{
+		UUID:       game.UUID,
+		SmallBlind: game.SmallBlind,
+		BigBlind:   game.BigBlind,
+		Ante:       game.Ante,
+		MinBuyIn:   game.MinBuyIn,
+		MaxBuyIn:   game.MaxBuyIn,
+		Structure:  game.Structure,
+		BuyIn:      game.BuyIn,
+		Seated:     len(game.Players),
+		MinPlayers: game.MinPlayers,
+		MaxPlayers: game.PlayerCap,
+		Running:    game.Playing,
+		SeatFree:   !game.Playing && !game.IsFull(),
+	}
+
+	stats, ok := con.gameSrv.Stats(game.UUID)
+	if !ok {
+		return listing
+	}
+
+	// A running table counts the players sitting at it, the bots aren't stored with the game
+	if game.Playing {
+		listing.Seated = stats.Seated
+		listing.SeatFree = !game.TableConfig().IsTournament() && stats.Seated < game.PlayerCap
+	}
+
+	listing.Hands = stats.Hands
+	listing.AveragePot = stats.AveragePot
+	listing.HandsPerHour = stats.HandsPerHour
+	return listing
+}
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/TypicalAM/gopoker/middleware"
 	"github.com/TypicalAM/gopoker/models"
@@ -23,3 +24,21 @@ func (con controller) getUser(c *gin.Context) (*models.User, error) {
 
 	return &user, nil
 }
+
+// maxPageSize is the maximum number of items on a single page
+const maxPageSize = 100
+
+// getPagination reads the page number and the page size from the query
+func getPagination(c *gin.Context) (int, int, error) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		return 0, 0, fmt.Errorf("invalid page")
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > maxPageSize {
+		return 0, 0, fmt.Errorf("invalid page size")
+	}
+
+	return page, limit, nil
+}
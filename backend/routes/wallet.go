@@ -0,0 +1,55 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/TypicalAM/gopoker/models"
+	"github.com/gin-gonic/gin"
+)
+
+// Wallet returns the user's balance and a page of the ledger history.
+func (con controller) Wallet(c *gin.Context) {
+	user, err := con.getUser(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user"})
+		return
+	}
+
+	page, limit, err := getPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	wallet, err := models.GetWallet(con.db, user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "There was an error finding your wallet. Please try again later."})
+		return
+	}
+
+	var total int64
+	if res := con.db.Model(&models.LedgerEntry{}).Where("wallet_id = ?", wallet.ID).Count(&total); res.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "There was an error finding your history. Please try again later."})
+		return
+	}
+
+	var entries []models.LedgerEntry
+	res := con.db.Where("wallet_id = ?", wallet.ID).Order("id desc").Offset((page - 1) * limit).Limit(limit).Find(&entries)
+	if res.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "There was an error finding your history. Please try again later."})
+		return
+	}
+
+	safeEntries := make([]models.SafeLedgerEntry, len(entries))
+	for i := range entries {
+		safeEntries[i] = entries[i].Sanitize()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"balance": wallet.Balance,
+		"entries": safeEntries,
+		"page":    page,
+		"limit":   limit,
+		"total":   total,
+	})
+}
@@ -0,0 +1,197 @@
+// Package bot implements the players the server seats at its tables.
+//
+// A bot is a Strategy. Every time the bot is the player to act, the table
+// calls Decide with a View of the hand: the game state sanitized for the
+// bot, so it only sees its own hole cards, and the moves the bot is allowed
+// to make. The bot returns a Decision, the amount of a bet or a raise is the
+// total bet the bot puts in front of it. A bot which returns an error folds.
+//
+// The built-in strategies are Random, TightAggressive and Equity. A bot can
+// also run in a separate process, see Process and Websocket for the protocol.
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TypicalAM/gopoker/texas"
+	"github.com/chehsunliu/poker"
+)
+
+// UnknownStrategyErr is returned when there is no strategy with the name.
+var UnknownStrategyErr = errors.New("unknown bot strategy")
+
+// NamePrefix starts the name of every bot, users can't register names starting with it.
+const NamePrefix = "bot-"
+
+// The names of the built-in strategies
+const (
+	RandomName          = "random"
+	TightAggressiveName = "tight-aggressive"
+	EquityName          = "equity"
+)
+
+// The prefixes of the strategies running in another process
+const (
+	execPrefix      = "exec:"
+	websocketPrefix = "ws://"
+)
+
+// names are given to the bots seated at a table, the lobby picks the first one that is free.
+var names = []string{"ada", "alan", "grace", "edsger", "barbara", "donald", "linus", "john", "margaret", "ken"}
+
+// Decision is the move of a bot, the amount is the total bet of a bet or a raise.
+type Decision struct {
+	Action texas.PokerAction `json:"action"`
+	Amount int               `json:"amount,omitempty"`
+}
+
+// Legal holds the moves the bot is allowed to make, see texas.Legal.
+type Legal = texas.Legal
+
+// View is what a bot knows about the hand when it is the player to act.
+type View struct {
+	Name  string             `json:"name"`
+	State *texas.TexasHoldEm `json:"state"`
+	Legal Legal              `json:"legal"`
+}
+
+// Strategy decides the moves of a bot.
+type Strategy interface {
+	Decide(view View) (Decision, error)
+}
+
+// New creates the built-in strategy with the name.
+func New(name string) (Strategy, error) {
+	switch name {
+	case RandomName:
+		return Random{}, nil
+	case TightAggressiveName:
+		return TightAggressive{}, nil
+	case EquityName:
+		return Equity{Simulations: 300}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", UnknownStrategyErr, name)
+}
+
+// Open creates the strategy from the spec: the name of a built-in strategy,
+// "exec:" followed by the command starting a bot process or the address of a
+// bot listening on a local websocket. The remote bots fold after the timeout.
+func Open(spec string, timeout time.Duration) (Strategy, error) {
+	switch {
+	case strings.HasPrefix(spec, execPrefix):
+		args := strings.Fields(strings.TrimPrefix(spec, execPrefix))
+		if len(args) == 0 {
+			return nil, fmt.Errorf("%w: missing command", UnknownStrategyErr)
+		}
+
+		return StartProcess(timeout, args[0], args[1:]...)
+
+	case strings.HasPrefix(spec, websocketPrefix):
+		return DialWebsocket(spec, timeout)
+	}
+
+	return New(spec)
+}
+
+// NewView creates the view of the hand for the bot, the state is sanitized for it.
+// The view doesn't share the board and the pots with the state, so the bot can
+// look at it while the hand goes on.
+func NewView(state *texas.TexasHoldEm, name string) View {
+	sanitized := state.SanitizeState(name)
+	sanitized.CommunityCards = append([]poker.Card{}, state.CommunityCards...)
+	sanitized.Pots = append([]texas.Pot{}, state.Pots...)
+	return View{
+		Name:  name,
+		State: sanitized,
+		Legal: state.LegalActions(name),
+	}
+}
+
+// IsBot returns true if the player name belongs to a bot.
+func IsBot(name string) bool {
+	return strings.HasPrefix(strings.ToLower(name), NamePrefix)
+}
+
+// Name returns a name for a new bot which isn't taken by any of the players.
+func Name(taken func(name string) bool) string {
+	for _, name := range names {
+		if !taken(NamePrefix + name) {
+			return NamePrefix + name
+		}
+	}
+
+	for i := len(names) + 1; ; i++ {
+		name := fmt.Sprintf("%s%d", NamePrefix, i)
+		if !taken(name) {
+			return name
+		}
+	}
+}
+
+// seat holds what a strategy needs to know about the bot's position in the hand.
+type seat struct {
+	index int
+	owed  int
+	pot   int
+	legal Legal
+}
+
+// findSeat returns the seat of the bot in the hand.
+func findSeat(view View) seat {
+	for i, player := range view.State.Players {
+		if player.Name != view.Name {
+			continue
+		}
+
+		return seat{
+			index: i,
+			owed:  view.State.ActiveBet - player.Bet,
+			pot:   view.State.PotTotal(),
+			legal: view.Legal,
+		}
+	}
+
+	return seat{index: -1}
+}
+
+// passive checks if it is allowed, otherwise it calls.
+func passive(s seat) Decision {
+	if s.legal.Allows(texas.Check) {
+		return Decision{Action: texas.Check}
+	}
+
+	return Decision{Action: texas.Call}
+}
+
+// fold folds if something is owed and plays passively otherwise, folding a free hand wastes it.
+func fold(s seat) Decision {
+	if s.owed == 0 {
+		return passive(s)
+	}
+
+	return Decision{Action: texas.Fold}
+}
+
+// raise bets or raises to the total bet clamped to the limits of the table.
+func raise(s seat, amount int) Decision {
+	action := texas.Raise
+	if s.legal.Allows(texas.Bet) {
+		action = texas.Bet
+	} else if !s.legal.Allows(texas.Raise) {
+		return passive(s)
+	}
+
+	if amount < s.legal.MinRaise {
+		amount = s.legal.MinRaise
+	}
+
+	if amount > s.legal.MaxRaise {
+		amount = s.legal.MaxRaise
+	}
+
+	return Decision{Action: action, Amount: amount}
+}
@@ -0,0 +1,215 @@
+package bot_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TypicalAM/gopoker/services/bot"
+	"github.com/TypicalAM/gopoker/texas"
+	"github.com/gorilla/websocket"
+)
+
+// TestStrategies plays hands between the built-in strategies and checks
+// that the engine accepts their moves.
+func TestStrategies(t *testing.T) {
+	strategies := map[string]bot.Strategy{}
+	config := texas.DefaultConfig()
+	game := texas.NewTexasHoldEm(config)
+	for _, name := range []string{bot.RandomName, bot.TightAggressiveName, bot.EquityName} {
+		strategy, err := bot.New(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		player := bot.Name(func(name string) bool {
+			_, ok := strategies[name]
+			return ok
+		})
+
+		strategies[player] = strategy
+		if err := game.AddPlayer(player, config.StartingStack); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := game.StartGame(); err != nil {
+		t.Fatal(err)
+	}
+
+	for hand := 0; hand < 20 && !game.IsGameOver(); hand++ {
+		for moves := 0; !game.IsHandOver(); moves++ {
+			if moves > 100 {
+				t.Fatalf("hand %d doesn't end", game.HandNumber)
+			}
+
+			name := game.Players[game.CurrentPlayer].Name
+			view := bot.NewView(game, name)
+			decision, err := strategies[name].Decide(view)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !view.Legal.Allows(decision.Action) {
+				t.Errorf("%s made a move which isn't legal: %s", name, decision.Action)
+			}
+
+			if err := game.AdvanceState(name, decision.Action, decision.Amount); err != nil {
+				t.Fatalf("%s cannot %s: %s", name, decision.Action, err)
+			}
+		}
+
+		// The broke bots are topped up like at the table
+		for _, player := range game.Players {
+			if player.Assets < config.MinBuyIn {
+				if err := game.TopUp(player.Name, config.MaxBuyIn-player.Assets); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+
+		if err := game.NextHand(); err != nil && !errors.Is(err, texas.NotEnoughPlayersErr) {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestNames tests the names given to the bots.
+func TestNames(t *testing.T) {
+	if _, err := bot.New("clairvoyant"); err == nil || !errors.Is(err, bot.UnknownStrategyErr) {
+		t.Errorf("expected unknown strategy error, got %v", err)
+	}
+
+	name := bot.Name(func(name string) bool { return name == "bot-ada" })
+	if name != "bot-alan" || !bot.IsBot(name) {
+		t.Errorf("expected the first free name to be bot-alan, got %s", name)
+	}
+
+	if bot.IsBot("robert") {
+		t.Errorf("expected robert not to be a bot")
+	}
+}
+
+// TestMain lets the test binary act as a bot process, the helper answers every request with a call.
+func TestMain(m *testing.M) {
+	if os.Getenv("BOT_HELPER_PROCESS") == "" {
+		os.Exit(m.Run())
+	}
+
+	decoder := json.NewDecoder(os.Stdin)
+	for {
+		var request bot.Request
+		if err := decoder.Decode(&request); err != nil {
+			os.Exit(0)
+		}
+
+		// The slow bot ignores the first request
+		if os.Getenv("BOT_HELPER_PROCESS") == "slow" && request.ID == 1 {
+			continue
+		}
+
+		fmt.Println("not a response")
+		fmt.Printf("{\"id\":%d,\"action\":\"call\"}\n", request.ID)
+	}
+}
+
+// startHelper starts the test binary as a bot process.
+func startHelper(t *testing.T, mode string) *bot.Process {
+	t.Helper()
+
+	t.Setenv("BOT_HELPER_PROCESS", mode)
+	process, err := bot.StartProcess(500*time.Millisecond, os.Args[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { process.Close() })
+	return process
+}
+
+// testView returns the view of the first player to act in a new game.
+func testView(t *testing.T) bot.View {
+	t.Helper()
+
+	game := texas.NewTexasHoldEm(texas.DefaultConfig())
+	for _, name := range []string{"bot-ada", "bot-alan", "bot-grace"} {
+		if err := game.AddPlayer(name, 100); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := game.StartGame(); err != nil {
+		t.Fatal(err)
+	}
+
+	return bot.NewView(game, game.Players[game.CurrentPlayer].Name)
+}
+
+// TestProcess tests the bots running in a separate process.
+func TestProcess(t *testing.T) {
+	view := testView(t)
+	if len(view.Legal.Actions) == 0 || view.Legal.Call != 2 {
+		t.Fatalf("expected the first player to owe the big blind, got %+v", view.Legal)
+	}
+
+	process := startHelper(t, "fast")
+	decision, err := process.Decide(view)
+	if err != nil || decision.Action != texas.Call {
+		t.Errorf("expected the bot to call, got %v %v", decision.Action, err)
+	}
+
+	slow := startHelper(t, "slow")
+	if decision, err := slow.Decide(view); err == nil || !errors.Is(err, bot.TimeoutErr) || decision.Action != texas.Fold {
+		t.Errorf("expected the bot to fold after the timeout, got %v %v", decision.Action, err)
+	}
+
+	if decision, err := slow.Decide(view); err != nil || decision.Action != texas.Call {
+		t.Errorf("expected the bot to answer the second request, got %v %v", decision.Action, err)
+	}
+}
+
+// TestWebsocket tests the bots listening on a local websocket.
+func TestWebsocket(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+		for {
+			var request bot.Request
+			if err := conn.ReadJSON(&request); err != nil {
+				return
+			}
+
+			response := bot.Response{ID: request.ID, Action: "raise", Amount: request.Legal.MinRaise}
+			if err := conn.WriteJSON(response); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	if _, err := bot.DialWebsocket("ws://example.com:8000", time.Second); err == nil || !errors.Is(err, bot.NotLocalErr) {
+		t.Errorf("expected not local error, got %v", err)
+	}
+
+	strategy, err := bot.Open("ws"+strings.TrimPrefix(server.URL, "http"), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	view := testView(t)
+	decision, err := strategy.Decide(view)
+	if err != nil || decision.Action != texas.Raise || decision.Amount != view.Legal.MinRaise {
+		t.Errorf("expected a minimum raise to %d, got %+v %v", view.Legal.MinRaise, decision, err)
+	}
+}
@@ -0,0 +1,213 @@
+package bot
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/TypicalAM/gopoker/texas"
+	"github.com/gorilla/websocket"
+)
+
+// TimeoutErr is returned when a remote bot doesn't answer in time.
+var TimeoutErr = errors.New("bot timed out")
+
+// ClosedErr is returned when the connection to a remote bot is gone.
+var ClosedErr = errors.New("bot connection closed")
+
+// NotLocalErr is returned when a websocket bot isn't running on this machine.
+var NotLocalErr = errors.New("bot is not local")
+
+// Request is sent to a remote bot when it has to make a move, the answer
+// is a Response with the same id. Every message is a single JSON object.
+type Request struct {
+	ID int `json:"id"`
+	View
+}
+
+// Response is the move of a remote bot, the action is one of the legal actions.
+type Response struct {
+	ID     int    `json:"id"`
+	Action string `json:"action"`
+	Amount int    `json:"amount,omitempty"`
+}
+
+// remote asks a bot running outside of the server for its moves. An answer
+// which doesn't come in time is skipped when it finally arrives.
+type remote struct {
+	mutex     sync.Mutex
+	timeout   time.Duration
+	lastID    int
+	send      func(request Request) error
+	responses chan Response
+}
+
+// newRemote creates a remote bot sending the requests with the function,
+// the responses are read with the other one until it fails.
+func newRemote(timeout time.Duration, send func(Request) error, read func() (Response, error)) *remote {
+	r := &remote{
+		timeout:   timeout,
+		send:      send,
+		responses: make(chan Response, 16),
+	}
+
+	go func() {
+		defer close(r.responses)
+		for {
+			response, err := read()
+			if err != nil {
+				return
+			}
+
+			r.responses <- response
+		}
+	}()
+
+	return r
+}
+
+// Decide sends the view to the bot and waits for its move, the moves are asked for one at a time.
+func (r *remote) Decide(view View) (Decision, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.lastID++
+	if err := r.send(Request{ID: r.lastID, View: view}); err != nil {
+		return Decision{Action: texas.Fold}, fmt.Errorf("%w: %s", ClosedErr, err)
+	}
+
+	timeout := time.NewTimer(r.timeout)
+	defer timeout.Stop()
+	for {
+		select {
+		case response, ok := <-r.responses:
+			if !ok {
+				return Decision{Action: texas.Fold}, ClosedErr
+			}
+
+			if response.ID != r.lastID {
+				// An answer to a request which has timed out
+				continue
+			}
+
+			action, ok := texas.DecodeAction(response.Action)
+			if !ok {
+				return Decision{Action: texas.Fold}, fmt.Errorf("%w: %s", texas.InvalidActionErr, response.Action)
+			}
+
+			return Decision{Action: action, Amount: response.Amount}, nil
+
+		case <-timeout.C:
+			return Decision{Action: texas.Fold}, TimeoutErr
+		}
+	}
+}
+
+// Process is a bot running in a child process. Every request is written to
+// its standard input as a line of JSON and the process writes the response
+// to its standard output as a line of JSON. Anything written to the standard
+// error is ignored.
+type Process struct {
+	*remote
+	cmd *exec.Cmd
+}
+
+// StartProcess starts the bot process with the command.
+func StartProcess(timeout time.Duration, name string, args ...string) (*Process, error) {
+	cmd := exec.Command(name, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	encoder := json.NewEncoder(stdin)
+	scanner := bufio.NewScanner(stdout)
+	read := func() (Response, error) {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return Response{}, err
+			}
+
+			return Response{}, io.EOF
+		}
+
+		// A line which isn't a response is skipped, its id never matches a request
+		var response Response
+		if err := json.Unmarshal(scanner.Bytes(), &response); err != nil {
+			return Response{}, nil
+		}
+
+		return response, nil
+	}
+
+	return &Process{
+		remote: newRemote(timeout, func(request Request) error { return encoder.Encode(request) }, read),
+		cmd:    cmd,
+	}, nil
+}
+
+// Close stops the bot process.
+func (p *Process) Close() error {
+	if err := p.cmd.Process.Kill(); err != nil {
+		return err
+	}
+
+	return p.cmd.Wait()
+}
+
+// Websocket is a bot listening on a websocket on this machine, the requests
+// and the responses are sent as text messages with a JSON object each.
+type Websocket struct {
+	*remote
+	conn *websocket.Conn
+}
+
+// DialWebsocket connects to the bot listening on the address, it has to be a loopback address.
+func DialWebsocket(address string, timeout time.Duration) (*Websocket, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if ip := net.ParseIP(u.Hostname()); u.Hostname() != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return nil, fmt.Errorf("%w: %s", NotLocalErr, u.Host)
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial(address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The reads happen in a single goroutine and the writes hold the lock of the remote
+	read := func() (Response, error) {
+		var response Response
+		err := conn.ReadJSON(&response)
+		return response, err
+	}
+
+	return &Websocket{
+		remote: newRemote(timeout, func(request Request) error { return conn.WriteJSON(request) }, read),
+		conn:   conn,
+	}, nil
+}
+
+// Close closes the connection to the bot.
+func (w *Websocket) Close() error {
+	return w.conn.Close()
+}
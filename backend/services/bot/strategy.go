@@ -0,0 +1,217 @@
+package bot
+
+import (
+	"math/rand"
+
+	"github.com/TypicalAM/gopoker/texas"
+	"github.com/chehsunliu/poker"
+)
+
+// The card ranks used by the preflop chart, the deuce is zero
+const (
+	rankNine  = 7
+	rankTen   = 8
+	rankQueen = 10
+	rankKing  = 11
+	rankAce   = 12
+)
+
+// The hand classes of the evaluator, a lower class is a better hand
+const (
+	classTwoPair = 7
+	classPair    = 8
+)
+
+// Random makes a random legal move, it is useful for testing the tables.
+type Random struct{}
+
+// Decide makes a random move, it calls more often than it folds or raises.
+func (Random) Decide(view View) (Decision, error) {
+	s := findSeat(view)
+	if s.index == -1 {
+		return Decision{Action: texas.Fold}, nil
+	}
+
+	roll := rand.Intn(100)
+	switch {
+	case roll < 25 && s.owed > 0:
+		return Decision{Action: texas.Fold}, nil
+	case roll >= 75:
+		return raise(s, s.legal.MinRaise+rand.Intn(s.pot+1)), nil
+	}
+
+	return passive(s), nil
+}
+
+// TightAggressive plays few hands preflop and bets the strong ones hard, it
+// follows a simple chart preflop and the class of its made hand afterwards.
+type TightAggressive struct{}
+
+// Decide plays the hand by the chart.
+func (TightAggressive) Decide(view View) (Decision, error) {
+	state := view.State
+	s := findSeat(view)
+	if s.index == -1 {
+		return Decision{Action: texas.Fold}, nil
+	}
+
+	holeCards := state.Players[s.index].HoleCards
+	if len(holeCards) != 2 {
+		return fold(s), nil
+	}
+
+	bigBlind := state.Config.BigBlind
+	if state.Round == texas.PreFlop {
+		switch preflopStrength(holeCards) {
+		case premium:
+			return raise(s, state.ActiveBet+3*bigBlind), nil
+		case playable:
+			if s.owed <= 4*bigBlind {
+				return passive(s), nil
+			}
+		}
+
+		return fold(s), nil
+	}
+
+	cards := append(append([]poker.Card{}, holeCards...), state.CommunityCards...)
+	switch class := poker.RankClass(poker.Evaluate(cards)); {
+	case class <= classTwoPair:
+		return raise(s, state.ActiveBet+2*s.pot/3), nil
+	case class == classPair && s.owed <= s.pot/2:
+		return passive(s), nil
+	}
+
+	return fold(s), nil
+}
+
+// The preflop hand groups of the tight-aggressive chart
+const (
+	trash = iota
+	playable
+	premium
+)
+
+// preflopStrength sorts the hole cards into a group of the preflop chart.
+func preflopStrength(holeCards []poker.Card) int {
+	high, low := holeCards[0].Rank(), holeCards[1].Rank()
+	if low > high {
+		high, low = low, high
+	}
+
+	suited := holeCards[0].Suit() == holeCards[1].Suit()
+	switch {
+	case high == low && high >= rankNine:
+		return premium
+	case high == rankAce && low >= rankQueen:
+		return premium
+	case high == low:
+		return playable
+	case low >= rankTen:
+		return playable
+	case suited && (high == rankAce || high == rankKing):
+		return playable
+	case suited && high-low == 1 && low >= 3:
+		return playable
+	}
+
+	return trash
+}
+
+// Equity estimates its chance of winning against the other players in the hand
+// by dealing out the hand many times, it calls when the pot odds are good and
+// raises when it is well ahead of its fair share.
+type Equity struct {
+	Simulations int
+}
+
+// Decide compares the estimated equity with the pot odds.
+func (e Equity) Decide(view View) (Decision, error) {
+	state := view.State
+	s := findSeat(view)
+	if s.index == -1 {
+		return Decision{Action: texas.Fold}, nil
+	}
+
+	opponents := 0
+	for i, player := range state.Players {
+		if i != s.index && player.Active {
+			opponents++
+		}
+	}
+
+	holeCards := state.Players[s.index].HoleCards
+	if len(holeCards) != 2 || opponents == 0 {
+		return passive(s), nil
+	}
+
+	equity := e.equity(holeCards, state.CommunityCards, opponents)
+	fairShare := 1 / float64(opponents+1)
+	switch {
+	case equity > 0.85 || equity > 2*fairShare:
+		return raise(s, state.ActiveBet+s.pot), nil
+	case s.owed == 0:
+		return passive(s), nil
+	case equity >= float64(s.owed)/float64(s.pot+s.owed):
+		return passive(s), nil
+	}
+
+	return Decision{Action: texas.Fold}, nil
+}
+
+// equity deals out the rest of the hand against random opponent cards and
+// returns the share of the pots the hole cards would win, ties are split.
+func (e Equity) equity(holeCards []poker.Card, board []poker.Card, opponents int) float64 {
+	known := append(append([]poker.Card{}, holeCards...), board...)
+	deck := []poker.Card{}
+	for _, card := range poker.NewDeck().Draw(52) {
+		if !containsCard(known, card) {
+			deck = append(deck, card)
+		}
+	}
+
+	simulations := e.Simulations
+	if simulations <= 0 {
+		simulations = 1
+	}
+
+	missing := 5 - len(board)
+	won := 0.0
+	for i := 0; i < simulations; i++ {
+		rand.Shuffle(len(deck), func(a, b int) {
+			deck[a], deck[b] = deck[b], deck[a]
+		})
+
+		fullBoard := append(append([]poker.Card{}, board...), deck[:missing]...)
+		drawn := deck[missing:]
+		best := poker.Evaluate(append(append([]poker.Card{}, holeCards...), fullBoard...))
+		ties := 1
+		lost := false
+		for j := 0; j < opponents && !lost; j++ {
+			score := poker.Evaluate(append(append([]poker.Card{}, drawn[2*j:2*j+2]...), fullBoard...))
+			switch {
+			case score < best:
+				lost = true
+			case score == best:
+				ties++
+			}
+		}
+
+		if !lost {
+			won += 1 / float64(ties)
+		}
+	}
+
+	return won / float64(simulations)
+}
+
+// containsCard returns true if the card is in the list.
+func containsCard(cards []poker.Card, card poker.Card) bool {
+	for _, c := range cards {
+		if c == card {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,113 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/TypicalAM/gopoker/models"
+)
+
+// ErrUnknownFormat is returned when the export format is not supported.
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// Format is a hand history file format.
+type Format string
+
+const (
+	PokerStars Format = "pokerstars"
+	JSON       Format = "json"
+)
+
+// handSeparator separates the hands in a PokerStars hand history file.
+const handSeparator = "\n\n\n"
+
+// ParseFormat decodes an export format from its name.
+func ParseFormat(text string) (Format, error) {
+	switch Format(text) {
+	case PokerStars, JSON:
+		return Format(text), nil
+	default:
+		return "", ErrUnknownFormat
+	}
+}
+
+// Extension returns the file extension of the format.
+func (f Format) Extension() string {
+	if f == JSON {
+		return ".json"
+	}
+
+	return ".txt"
+}
+
+// ContentType returns the mime type of the format.
+func (f Format) ContentType() string {
+	if f == JSON {
+		return "application/json"
+	}
+
+	return "text/plain; charset=utf-8"
+}
+
+// Hand renders a single hand, the hero is the player whose hole cards are dealt face up.
+func Hand(format Format, hand models.SafeHand, hero string) ([]byte, error) {
+	switch format {
+	case PokerStars:
+		return []byte(pokerStarsHand(hand, hero)), nil
+	case JSON:
+		return json.MarshalIndent(hand, "", "  ")
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// Writer writes hands one after another into a single hand history file.
+// The PokerStars hands are separated by blank lines and the JSON hands are written one per line.
+type Writer struct {
+	w      io.Writer
+	format Format
+	hero   string
+	count  int
+}
+
+// NewWriter creates a new hand history writer.
+func NewWriter(w io.Writer, format Format, hero string) (*Writer, error) {
+	if _, err := ParseFormat(string(format)); err != nil {
+		return nil, err
+	}
+
+	return &Writer{w: w, format: format, hero: hero}, nil
+}
+
+// Write appends the hand to the file.
+func (w *Writer) Write(hand models.SafeHand) error {
+	var data []byte
+	var err error
+	switch w.format {
+	case PokerStars:
+		data = []byte(pokerStarsHand(hand, w.hero))
+		if w.count > 0 {
+			data = append([]byte(handSeparator), data...)
+		}
+
+	case JSON:
+		if data, err = json.Marshal(hand); err != nil {
+			return err
+		}
+
+		data = append(data, '\n')
+	}
+
+	if _, err = w.w.Write(data); err != nil {
+		return err
+	}
+
+	w.count++
+	return nil
+}
+
+// Count returns the number of hands written.
+func (w *Writer) Count() int {
+	return w.count
+}
@@ -0,0 +1,251 @@
+package export
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/TypicalAM/gopoker/models"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// testPlayedAt is the time every test hand was played at.
+var testPlayedAt = time.Date(2026, 3, 14, 18, 30, 0, 0, time.UTC)
+
+// testSeats seats alice on the button, bob in the small blind and carol in the big blind.
+func testSeats(stacks ...int) []models.SafeHandSeat {
+	names := []string{"alice", "bob", "carol"}
+	seats := make([]models.SafeHandSeat, len(stacks))
+	for i, stack := range stacks {
+		seats[i] = models.SafeHandSeat{Seat: i, Username: names[i], Stack: stack, HoleCards: []string{}}
+	}
+
+	return seats
+}
+
+// action creates an action of the hand.
+func action(round string, username string, name string, amount int, bet int) models.SafeHandAction {
+	return models.SafeHandAction{Round: round, Username: username, Action: name, Amount: amount, Bet: bet}
+}
+
+// showdownHand is checked down to the river, where bob's pair beats carol.
+func showdownHand() models.SafeHand {
+	hand := models.SafeHand{
+		ID:         101,
+		GameUUID:   "5f0c3a7e-2d4b-4c1e-9a8f-7b6d5e4c3b2a",
+		Number:     1,
+		Structure:  "no-limit",
+		TableSize:  6,
+		SmallBlind: 1,
+		BigBlind:   2,
+		Dealer:     0,
+		Board:      []string{"Ah", "Kd", "7c", "2s", "9h"},
+		Seats:      testSeats(100, 100, 100),
+		PlayedAt:   testPlayedAt,
+		Actions: []models.SafeHandAction{
+			action("preflop", "bob", "small blind", 1, 1),
+			action("preflop", "carol", "big blind", 2, 2),
+			action("preflop", "alice", "call", 2, 2),
+			action("preflop", "bob", "call", 1, 2),
+			action("preflop", "carol", "check", 0, 2),
+			action("flop", "bob", "check", 0, 0),
+			action("flop", "carol", "bet", 4, 4),
+			action("flop", "alice", "fold", 0, 0),
+			action("flop", "bob", "call", 4, 4),
+			action("turn", "bob", "check", 0, 0),
+			action("turn", "carol", "check", 0, 0),
+			action("river", "bob", "bet", 10, 10),
+			action("river", "carol", "call", 10, 10),
+		},
+		Pots: []models.SafeHandPot{{Amount: 34, Winners: []string{"bob"}}},
+	}
+
+	hand.Seats[1].HoleCards = []string{"As", "Qd"}
+	hand.Seats[1].Shown = true
+	hand.Seats[1].Won = 34
+	hand.Seats[1].Rank = "Pair"
+	hand.Seats[2].HoleCards = []string{"Kh", "Jc"}
+	hand.Seats[2].Shown = true
+	hand.Seats[2].Rank = "Pair"
+	return hand
+}
+
+// foldedHand is won by alice's raise before the flop, carol only sees her own cards.
+func foldedHand() models.SafeHand {
+	hand := models.SafeHand{
+		ID:         102,
+		GameUUID:   "5f0c3a7e-2d4b-4c1e-9a8f-7b6d5e4c3b2a",
+		Number:     2,
+		Structure:  "no-limit",
+		TableSize:  6,
+		SmallBlind: 1,
+		BigBlind:   2,
+		Dealer:     0,
+		Board:      []string{},
+		Seats:      testSeats(98, 117, 85),
+		PlayedAt:   testPlayedAt.Add(time.Minute),
+		Actions: []models.SafeHandAction{
+			action("preflop", "bob", "small blind", 1, 1),
+			action("preflop", "carol", "big blind", 2, 2),
+			action("preflop", "alice", "raise", 6, 6),
+			action("preflop", "bob", "fold", 0, 1),
+			action("preflop", "carol", "fold", 0, 2),
+		},
+		Pots: []models.SafeHandPot{{Amount: 9, Winners: []string{"alice"}}},
+	}
+
+	hand.Seats[0].Won = 9
+	hand.Seats[2].HoleCards = []string{"8c", "3d"}
+	return hand
+}
+
+// sidePotHand has carol all-in for less, she wins the main pot and alice wins the side pot.
+func sidePotHand() models.SafeHand {
+	hand := models.SafeHand{
+		ID:         103,
+		GameUUID:   "5f0c3a7e-2d4b-4c1e-9a8f-7b6d5e4c3b2a",
+		Number:     3,
+		Structure:  "pot-limit",
+		TableSize:  3,
+		SmallBlind: 1,
+		BigBlind:   2,
+		Dealer:     0,
+		Board:      []string{"Qs", "Td", "4h", "4c", "2d"},
+		Seats:      testSeats(150, 120, 20),
+		PlayedAt:   testPlayedAt.Add(2 * time.Minute),
+		Actions: []models.SafeHandAction{
+			action("preflop", "bob", "small blind", 1, 1),
+			action("preflop", "carol", "big blind", 2, 2),
+			action("preflop", "alice", "raise", 50, 50),
+			action("preflop", "bob", "call", 49, 50),
+			action("preflop", "carol", "allin", 18, 20),
+			action("flop", "bob", "check", 0, 0),
+			action("flop", "alice", "check", 0, 0),
+			action("turn", "bob", "check", 0, 0),
+			action("turn", "alice", "check", 0, 0),
+			action("river", "bob", "check", 0, 0),
+			action("river", "alice", "check", 0, 0),
+		},
+		Pots: []models.SafeHandPot{
+			{Amount: 60, Winners: []string{"carol"}},
+			{Amount: 60, Winners: []string{"alice"}},
+		},
+	}
+
+	hand.Seats[0].HoleCards = []string{"Qh", "Qc"}
+	hand.Seats[0].Shown = true
+	hand.Seats[0].Won = 60
+	hand.Seats[0].Rank = "Full House"
+	hand.Seats[1].HoleCards = []string{"Jh", "Jd"}
+	hand.Seats[1].Shown = true
+	hand.Seats[1].Rank = "Two Pair"
+	hand.Seats[2].HoleCards = []string{"4s", "4d"}
+	hand.Seats[2].Shown = true
+	hand.Seats[2].Won = 60
+	hand.Seats[2].Rank = "Four of a Kind"
+	return hand
+}
+
+// checkGolden compares the output with the golden file, the -update flag rewrites the file.
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("cannot update %s: %s", path, err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("cannot read %s: %s", path, err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s doesn't match the golden file:\n%s", name, got)
+	}
+}
+
+func TestPokerStarsHand(t *testing.T) {
+	cases := []struct {
+		name   string
+		hand   models.SafeHand
+		hero   string
+		golden string
+	}{
+		{"showdown", showdownHand(), "bob", "showdown.txt"},
+		{"won before the showdown", foldedHand(), "carol", "folded.txt"},
+		{"side pots", sidePotHand(), "alice", "side_pots.txt"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := Hand(PokerStars, tc.hand, tc.hero)
+			if err != nil {
+				t.Fatalf("cannot export the hand: %s", err)
+			}
+
+			checkGolden(t, tc.golden, data)
+		})
+	}
+}
+
+func TestJSONHand(t *testing.T) {
+	data, err := Hand(JSON, showdownHand(), "bob")
+	if err != nil {
+		t.Fatalf("cannot export the hand: %s", err)
+	}
+
+	checkGolden(t, "showdown.json", data)
+}
+
+func TestWriter(t *testing.T) {
+	hands := []models.SafeHand{showdownHand(), foldedHand(), sidePotHand()}
+	for _, tc := range []struct {
+		format Format
+		golden string
+	}{
+		{PokerStars, "session.txt"},
+		{JSON, "session.jsonl"},
+	} {
+		t.Run(string(tc.format), func(t *testing.T) {
+			var b bytes.Buffer
+			w, err := NewWriter(&b, tc.format, "alice")
+			if err != nil {
+				t.Fatalf("cannot create the writer: %s", err)
+			}
+
+			for _, hand := range hands {
+				if err := w.Write(hand); err != nil {
+					t.Fatalf("cannot write the hand: %s", err)
+				}
+			}
+
+			if w.Count() != len(hands) {
+				t.Errorf("expected %d hands, got %d", len(hands), w.Count())
+			}
+
+			checkGolden(t, tc.golden, b.Bytes())
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if _, err := ParseFormat("html"); err != ErrUnknownFormat {
+		t.Errorf("expected an unknown format, got %v", err)
+	}
+
+	if _, err := NewWriter(&bytes.Buffer{}, Format("html"), "alice"); err != ErrUnknownFormat {
+		t.Errorf("expected the writer to reject an unknown format, got %v", err)
+	}
+
+	if _, err := Hand(Format("html"), showdownHand(), "alice"); err != ErrUnknownFormat {
+		t.Errorf("expected the export to reject an unknown format, got %v", err)
+	}
+}
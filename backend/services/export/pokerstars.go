@@ -0,0 +1,328 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TypicalAM/gopoker/models"
+	"github.com/TypicalAM/gopoker/texas"
+)
+
+// timeFormat is the timestamp format of the PokerStars hand header.
+const timeFormat = "2006/01/02 15:04:05"
+
+// streets are the betting rounds with the number of community cards they show.
+var streets = []struct {
+	round string
+	name  string
+	cards int
+}{
+	{string(texas.Flop), "FLOP", 3},
+	{string(texas.Turn), "TURN", 4},
+	{string(texas.River), "RIVER", 5},
+}
+
+var limitNames = map[string]string{
+	string(texas.NoLimit):    "No Limit",
+	string(texas.PotLimit):   "Pot Limit",
+	string(texas.FixedLimit): "Limit",
+}
+
+// pokerStarsHand renders the hand in the PokerStars hand history format.
+func pokerStarsHand(hand models.SafeHand, hero string) string {
+	var b strings.Builder
+
+	limit, ok := limitNames[hand.Structure]
+	if !ok {
+		limit = limitNames[string(texas.NoLimit)]
+	}
+
+	tableSize := hand.TableSize
+	if tableSize < len(hand.Seats) {
+		tableSize = len(hand.Seats)
+	}
+
+	fmt.Fprintf(&b, "PokerStars Hand #%d: Hold'em %s (%d/%d) - %s\n", hand.ID, limit, hand.SmallBlind, hand.BigBlind, playedAt(hand.PlayedAt))
+	fmt.Fprintf(&b, "Table '%s' %d-max Seat #%d is the button\n", tableName(hand.GameUUID), tableSize, hand.Dealer+1)
+	for _, seat := range hand.Seats {
+		fmt.Fprintf(&b, "Seat %d: %s (%d in chips)\n", seat.Seat+1, seat.Username, seat.Stack)
+	}
+
+	stacks := make(map[string]int, len(hand.Seats))
+	for _, seat := range hand.Seats {
+		stacks[seat.Username] = seat.Stack
+	}
+
+	highest := 0
+	writeActions := func(actions []models.SafeHandAction) {
+		for _, action := range actions {
+			stacks[action.Username] -= action.Amount
+			fmt.Fprintf(&b, "%s: %s", action.Username, describeAction(action, highest))
+			if stacks[action.Username] == 0 && action.Amount > 0 {
+				b.WriteString(" and is all-in")
+			}
+
+			b.WriteString("\n")
+			if action.Bet > highest {
+				highest = action.Bet
+			}
+		}
+	}
+
+	preflop := roundActions(hand.Actions, string(texas.PreFlop))
+	posts := 0
+	for posts < len(preflop) && isPost(preflop[posts].Action) {
+		posts++
+	}
+
+	writeActions(preflop[:posts])
+	b.WriteString("*** HOLE CARDS ***\n")
+	for _, seat := range hand.Seats {
+		if seat.Username == hero && len(seat.HoleCards) > 0 {
+			fmt.Fprintf(&b, "Dealt to %s [%s]\n", seat.Username, strings.Join(seat.HoleCards, " "))
+		}
+	}
+
+	writeActions(preflop[posts:])
+	for _, street := range streets {
+		if len(hand.Board) < street.cards {
+			break
+		}
+
+		highest = 0
+		if street.cards == 3 {
+			fmt.Fprintf(&b, "*** %s *** [%s]\n", street.name, strings.Join(hand.Board[:3], " "))
+		} else {
+			fmt.Fprintf(&b, "*** %s *** [%s] [%s]\n", street.name, strings.Join(hand.Board[:street.cards-1], " "), hand.Board[street.cards-1])
+		}
+
+		writeActions(roundActions(hand.Actions, street.round))
+	}
+
+	// The engine keeps the bet nobody called in the pot, the export gives it back like PokerStars does
+	pots := make([]models.SafeHandPot, len(hand.Pots))
+	copy(pots, hand.Pots)
+	bettor, uncalled := uncalledBet(hand)
+	if uncalled > 0 {
+		fmt.Fprintf(&b, "Uncalled bet (%d) returned to %s\n", uncalled, bettor)
+		for i := len(pots) - 1; i >= 0; i-- {
+			if contains(pots[i].Winners, bettor) {
+				pots[i].Amount -= uncalled
+				break
+			}
+		}
+	}
+
+	showdown := false
+	for _, seat := range hand.Seats {
+		if seat.Shown {
+			showdown = true
+		}
+	}
+
+	if showdown {
+		b.WriteString("*** SHOW DOWN ***\n")
+		for _, seat := range hand.Seats {
+			if !seat.Shown {
+				continue
+			}
+
+			fmt.Fprintf(&b, "%s: shows [%s]", seat.Username, strings.Join(seat.HoleCards, " "))
+			if seat.Rank != "" {
+				fmt.Fprintf(&b, " (%s)", seat.Rank)
+			}
+
+			b.WriteString("\n")
+		}
+	}
+
+	total := 0
+	for i, pot := range pots {
+		total += pot.Amount
+		for j, winner := range pot.Winners {
+			share := pot.Amount / len(pot.Winners)
+			if j < pot.Amount%len(pot.Winners) {
+				share++
+			}
+
+			fmt.Fprintf(&b, "%s collected %d from %s\n", winner, share, potName(i, len(pots)))
+		}
+	}
+
+	b.WriteString("*** SUMMARY ***\n")
+	fmt.Fprintf(&b, "Total pot %d | Rake 0\n", total)
+	if len(hand.Board) > 0 {
+		fmt.Fprintf(&b, "Board [%s]\n", strings.Join(hand.Board, " "))
+	}
+
+	for _, seat := range hand.Seats {
+		if seat.Username == bettor && seat.Won >= uncalled {
+			seat.Won -= uncalled
+		}
+
+		fmt.Fprintf(&b, "Seat %d: %s%s %s\n", seat.Seat+1, seat.Username, seatPosition(hand, seat), seatResult(hand, seat))
+	}
+
+	return b.String()
+}
+
+// uncalledBet returns the player who put the most chips into the pot and the part
+// of them nobody else matched, which goes back to the player.
+func uncalledBet(hand models.SafeHand) (string, int) {
+	contributed := make(map[string]int, len(hand.Seats))
+	for _, action := range hand.Actions {
+		contributed[action.Username] += action.Amount
+	}
+
+	bettor, highest, second := "", 0, 0
+	for _, seat := range hand.Seats {
+		amount := contributed[seat.Username]
+		switch {
+		case amount > highest:
+			bettor, highest, second = seat.Username, amount, highest
+		case amount > second:
+			second = amount
+		}
+	}
+
+	return bettor, highest - second
+}
+
+// contains checks if the name is in the list.
+func contains(names []string, name string) bool {
+	for _, other := range names {
+		if other == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// describeAction renders the action, the highest is the largest bet on the street before the action.
+func describeAction(action models.SafeHandAction, highest int) string {
+	switch texas.PokerAction(action.Action) {
+	case texas.PostAnte:
+		return fmt.Sprintf("posts the ante %d", action.Amount)
+	case texas.PostSmallBlind:
+		return fmt.Sprintf("posts small blind %d", action.Amount)
+	case texas.PostBigBlind:
+		return fmt.Sprintf("posts big blind %d", action.Amount)
+	case texas.Fold:
+		return "folds"
+	}
+
+	switch {
+	case action.Amount == 0:
+		return "checks"
+	case action.Bet <= highest:
+		return fmt.Sprintf("calls %d", action.Amount)
+	case highest == 0:
+		return fmt.Sprintf("bets %d", action.Amount)
+	default:
+		return fmt.Sprintf("raises %d to %d", action.Bet-highest, action.Bet)
+	}
+}
+
+// seatPosition renders the position of the seat in the summary.
+func seatPosition(hand models.SafeHand, seat models.SafeHandSeat) string {
+	if seat.Seat == hand.Dealer {
+		return " (button)"
+	}
+
+	for _, action := range hand.Actions {
+		if action.Username != seat.Username {
+			continue
+		}
+
+		switch texas.PokerAction(action.Action) {
+		case texas.PostSmallBlind:
+			return " (small blind)"
+		case texas.PostBigBlind:
+			return " (big blind)"
+		}
+	}
+
+	return ""
+}
+
+// seatResult renders how the hand ended for the seat in the summary.
+func seatResult(hand models.SafeHand, seat models.SafeHandSeat) string {
+	cards := strings.Join(seat.HoleCards, " ")
+	switch {
+	case seat.Won > 0 && seat.Shown:
+		return fmt.Sprintf("showed [%s] and won (%d) with %s", cards, seat.Won, seat.Rank)
+	case seat.Won > 0:
+		return fmt.Sprintf("collected (%d)", seat.Won)
+	case seat.Shown:
+		return fmt.Sprintf("showed [%s] and lost", cards)
+	}
+
+	for _, action := range hand.Actions {
+		if action.Username != seat.Username || texas.PokerAction(action.Action) != texas.Fold {
+			continue
+		}
+
+		if action.Round == string(texas.PreFlop) {
+			return "folded before Flop"
+		}
+
+		return fmt.Sprintf("folded on the %s", strings.ToUpper(action.Round[:1])+action.Round[1:])
+	}
+
+	return "mucked"
+}
+
+// roundActions returns the actions taken in the round.
+func roundActions(actions []models.SafeHandAction, round string) []models.SafeHandAction {
+	result := []models.SafeHandAction{}
+	for _, action := range actions {
+		if action.Round == round {
+			result = append(result, action)
+		}
+	}
+
+	return result
+}
+
+// isPost checks if the action is a forced bet.
+func isPost(action string) bool {
+	switch texas.PokerAction(action) {
+	case texas.PostAnte, texas.PostSmallBlind, texas.PostBigBlind:
+		return true
+	default:
+		return false
+	}
+}
+
+// potName names the pot like PokerStars does.
+func potName(index int, count int) string {
+	switch {
+	case count == 1:
+		return "pot"
+	case index == 0:
+		return "main pot"
+	default:
+		return fmt.Sprintf("side pot-%d", index)
+	}
+}
+
+// tableName shortens the game uuid into a table name.
+func tableName(uuid string) string {
+	if len(uuid) > 8 {
+		return uuid[:8]
+	}
+
+	return uuid
+}
+
+// playedAt formats the time the hand was played in the eastern time, like the PokerStars clients do.
+func playedAt(t time.Time) string {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return t.UTC().Format(timeFormat) + " UTC"
+	}
+
+	return t.In(location).Format(timeFormat) + " ET"
+}
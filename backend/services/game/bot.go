@@ -0,0 +1,195 @@
+package game
+
+import (
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/TypicalAM/gopoker/services/bot"
+	"github.com/TypicalAM/gopoker/texas"
+)
+
+// scheduleBackfill fills the empty seats of a cash table with bots if not
+// enough players sit down in time.
+func (l *lobby) scheduleBackfill() {
+	if l.backfillWait == 0 || l.backfillTimer != nil || l.texas.HandNumber > 0 || l.texas.Config.IsTournament() {
+		return
+	}
+
+	log.Printf("[%s] Filling the table with bots in %s", l.uuid[:10], l.backfillWait)
+	l.backfillTimer = time.AfterFunc(l.backfillWait, l.backfill)
+}
+
+// backfill seats the bots needed to start the game.
+func (l *lobby) backfill() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.backfillTimer = nil
+	if _, ok := l.srv.games.load(l.uuid); !ok {
+		// The game has already been deleted
+		return
+	}
+
+	if l.texas.HandNumber > 0 {
+		return
+	}
+
+	for len(l.texas.Players) < l.texas.RequiredPlayers() {
+		if err := l.addBot(l.srv.botStrategy); err != nil {
+			log.Printf("[%s] Cannot add a bot to the table: %s", l.uuid[:10], err)
+			return
+		}
+	}
+
+	l.broadcast()
+	l.startGame()
+}
+
+// addBot seats a bot playing with the strategy, the bot plays with the chips of the house.
+func (l *lobby) addBot(strategy bot.Strategy) error {
+	name := bot.Name(func(name string) bool {
+		_, ok := l.texas.FindPlayer(name)
+		return ok
+	})
+
+	if err := l.texas.AddPlayer(name, l.texas.Config.StartingStack); err != nil {
+		return err
+	}
+
+	log.Printf("[%s] Seated %s", l.uuid[:10], name)
+	l.bots[name] = strategy
+	return nil
+}
+
+// scheduleBot lets the bot to act think about its move. If nobody has acted
+// and the same bot is still to act it keeps thinking.
+func (l *lobby) scheduleBot(acted bool) {
+	if l.texas.HandNumber == 0 || l.texas.IsHandOver() {
+		l.stopBot()
+		return
+	}
+
+	player := l.texas.Players[l.texas.CurrentPlayer].Name
+	if !acted && l.botTimer != nil && l.botPlayer == player {
+		return
+	}
+
+	l.stopBot()
+	if _, ok := l.bots[player]; !ok {
+		return
+	}
+
+	// The bots don't all take the same time to act
+	think := l.srv.botThink / 2
+	if l.srv.botThink > 0 {
+		think += time.Duration(rand.Int63n(int64(l.srv.botThink)))
+	}
+
+	l.botSeq++
+	seq := l.botSeq
+	l.botPlayer = player
+	l.botTimer = time.AfterFunc(think, func() {
+		l.botMove(seq)
+	})
+}
+
+// stopBot stops the bot from acting.
+func (l *lobby) stopBot() {
+	if l.botTimer == nil {
+		return
+	}
+
+	l.botTimer.Stop()
+	l.botTimer = nil
+	l.botSeq++
+	l.botPlayer = ""
+}
+
+// botMove asks the bot for its move and makes it. The table isn't locked while
+// the bot is thinking, a bot which fails to answer folds and a move the engine
+// doesn't allow is replaced with a call.
+func (l *lobby) botMove(seq int) {
+	l.mutex.Lock()
+	if seq != l.botSeq {
+		// The hand has moved on in the meantime
+		l.mutex.Unlock()
+		return
+	}
+
+	if _, ok := l.srv.games.load(l.uuid); !ok {
+		// The game has already been deleted
+		l.mutex.Unlock()
+		return
+	}
+
+	name := l.botPlayer
+	strategy := l.bots[name]
+	view := bot.NewView(l.texas, name)
+	l.mutex.Unlock()
+
+	decision, err := strategy.Decide(view)
+	if err != nil {
+		log.Printf("[%s] %s cannot decide, folding: %s", l.uuid[:10], name, err)
+		decision = bot.Decision{Action: texas.Fold}
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if seq != l.botSeq {
+		// The hand has moved on while the bot was thinking
+		return
+	}
+
+	if _, ok := l.srv.games.load(l.uuid); !ok {
+		// The game has already been deleted
+		return
+	}
+
+	l.botTimer = nil
+	if err := l.texas.AdvanceState(name, decision.Action, decision.Amount); err != nil {
+		log.Printf("[%s] %s cannot %s, calling instead: %s", l.uuid[:10], name, decision.Action, err)
+		if err := l.texas.AdvanceState(name, texas.Call, 0); err != nil {
+			log.Printf("[%s] %s cannot call: %s", l.uuid[:10], name, err)
+			return
+		}
+	}
+
+	l.updateTurnClock(true)
+	l.broadcast()
+	l.scheduleNextHand()
+}
+
+// rebuyBots tops up the bots who dropped below the minimum buy-in to the maximum one.
+func (l *lobby) rebuyBots() {
+	if l.texas.Config.IsTournament() {
+		return
+	}
+
+	for name := range l.bots {
+		player, ok := l.texas.FindPlayer(name)
+		if !ok || player.Assets >= l.texas.Config.MinBuyIn {
+			continue
+		}
+
+		if err := l.texas.TopUp(name, l.texas.Config.MaxBuyIn-player.Assets); err != nil {
+			log.Printf("[%s] Cannot top up %s: %s", l.uuid[:10], name, err)
+		}
+	}
+}
+
+// botsOnly returns true if every player left at the table is a bot, nobody would watch them play.
+func (l *lobby) botsOnly() bool {
+	if len(l.bots) == 0 {
+		return false
+	}
+
+	for _, player := range l.texas.Players {
+		if _, ok := l.bots[player.Name]; !ok && !player.Left {
+			return false
+		}
+	}
+
+	return true
+}
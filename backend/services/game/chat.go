@@ -0,0 +1,154 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// Maximum length of a chat message in characters.
+	maxChatLength = 200
+
+	// Number of chat messages replayed to the clients who join the table.
+	chatHistorySize = 50
+
+	// A user can send at most chatRateLimit messages every chatRateWindow.
+	chatRateLimit  = 5
+	chatRateWindow = 10 * time.Second
+)
+
+// ChatFilter checks a chat message before it is sent to the table,
+// it can change the text or reject the message with an error.
+type ChatFilter func(text string) (string, error)
+
+// WordFilter creates a chat filter which masks the words with asterisks.
+func WordFilter(words []string) ChatFilter {
+	patterns := []*regexp.Regexp{}
+	for _, word := range words {
+		word = strings.TrimSpace(word)
+		if word != "" {
+			patterns = append(patterns, regexp.MustCompile(`(?i)\b`+regexp.QuoteMeta(word)+`\b`))
+		}
+	}
+
+	return func(text string) (string, error) {
+		for _, pattern := range patterns {
+			text = pattern.ReplaceAllStringFunc(text, func(match string) string {
+				return strings.Repeat("*", utf8.RuneCountInString(match))
+			})
+		}
+
+		return text, nil
+	}
+}
+
+// ChatMessage is a chat message sent to the table.
+type ChatMessage struct {
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	Text        string    `json:"text"`
+	Time        time.Time `json:"time"`
+	Spectator   bool      `json:"spectator"`
+}
+
+// chat sends the client's message to the players and the spectators.
+func (l *lobby) chat(client *Client, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		l.send(client, &GameMessage{
+			Type: MsgError,
+			Data: "Empty chat message",
+		})
+		return
+	}
+
+	if utf8.RuneCountInString(text) > maxChatLength {
+		l.send(client, &GameMessage{
+			Type: MsgError,
+			Data: fmt.Sprintf("Chat messages can be at most %d characters long", maxChatLength),
+		})
+		return
+	}
+
+	if !l.allowChat(client.user.Username) {
+		l.send(client, &GameMessage{
+			Type: MsgError,
+			Data: "You are sending messages too quickly",
+		})
+		return
+	}
+
+	text, err := l.srv.chatFilter(text)
+	if err != nil {
+		l.send(client, &GameMessage{
+			Type: MsgError,
+			Data: err.Error(),
+		})
+		return
+	}
+
+	displayName := client.user.Profile.DisplayName
+	if displayName == "" {
+		displayName = client.user.Username
+	}
+
+	chatMsg := ChatMessage{
+		Username:    client.user.Username,
+		DisplayName: displayName,
+		Text:        text,
+		Time:        time.Now(),
+		Spectator:   client.spectator,
+	}
+
+	l.chatHistory = append(l.chatHistory, chatMsg)
+	if len(l.chatHistory) > chatHistorySize {
+		l.chatHistory = l.chatHistory[len(l.chatHistory)-chatHistorySize:]
+	}
+
+	for _, c := range l.clients {
+		l.sendChat(c, chatMsg)
+	}
+
+	for _, c := range l.spectators {
+		l.sendChat(c, chatMsg)
+	}
+}
+
+// allowChat checks the rate limit of the user and counts the message.
+func (l *lobby) allowChat(username string) bool {
+	now := time.Now()
+	recent := []time.Time{}
+	for _, sent := range l.chatTimes[username] {
+		if now.Sub(sent) < chatRateWindow {
+			recent = append(recent, sent)
+		}
+	}
+
+	if len(recent) >= chatRateLimit {
+		l.chatTimes[username] = recent
+		return false
+	}
+
+	l.chatTimes[username] = append(recent, now)
+	return true
+}
+
+// replayChat sends the recent chat messages to the client.
+func (l *lobby) replayChat(client *Client) {
+	for _, chatMsg := range l.chatHistory {
+		l.sendChat(client, chatMsg)
+	}
+}
+
+// sendChat sends a chat message to the client.
+func (l *lobby) sendChat(client *Client, chatMsg ChatMessage) {
+	chatBytes, _ := json.Marshal(chatMsg)
+	l.send(client, &GameMessage{
+		Type: MsgChat,
+		Data: string(chatBytes),
+	})
+}
@@ -21,7 +21,7 @@ const (
 	pingPeriod = (pongWait * 9) / 10
 
 	// Maximum message size allowed from peer.
-	maxMessageSize = 512
+	maxMessageSize = 2048
 )
 
 var (
@@ -36,21 +36,35 @@ const (
 	MsgError  msgType = "error"
 	MsgInput  msgType = "input"
 	MsgAction msgType = "action"
+	MsgChat   msgType = "chat"
+	MsgSeat   msgType = "seat"
+	MsgSitOut msgType = "sitout"
+	MsgSitIn  msgType = "sitin"
+	MsgLeave  msgType = "leave"
+	MsgTopUp  msgType = "topup"
+	MsgMove   msgType = "move"
+	MsgSeed   msgType = "seed"
 )
 
 // GameMessage is a message that is used to communicate between the player and the game server.
+// Amount is the raise size, the chosen seat, the number of hands to sit out or the chips
+// to top up with, the data of a chat message sent by the server is a ChatMessage. The data
+// of a seed message is the entropy the player adds to the shuffles of the next hands.
 type GameMessage struct {
-	Type msgType `json:"type"`
-	Data string  `json:"data"`
+	Type   msgType `json:"type"`
+	Data   string  `json:"data"`
+	Amount int     `json:"amount,omitempty"`
 }
 
 // Client is a middleman between the websocket connection and the hub.
+// Spectators only receive the delayed state and cannot act.
 type Client struct {
-	srv   *Server
-	lobby *lobby
-	user  *models.User
-	conn  *websocket.Conn
-	send  chan GameMessage
+	srv       *Server
+	lobby     *lobby
+	user      *models.User
+	conn      *websocket.Conn
+	send      chan GameMessage
+	spectator bool
 }
 
 // Connect takes the websocket connection and bootstraps the client
@@ -0,0 +1,116 @@
+package game
+
+import (
+	"log"
+	"time"
+)
+
+// updateTurnClock starts the clock of the player to act. If nobody has acted
+// and the same player is still to act their clock keeps running. A bot to act
+// starts thinking about its move.
+func (l *lobby) updateTurnClock(acted bool) {
+	l.scheduleBot(acted)
+	if l.texas.Config.ActionTime == 0 || l.texas.HandNumber == 0 || l.texas.IsHandOver() {
+		l.stopTurnClock()
+		return
+	}
+
+	player := l.texas.Players[l.texas.CurrentPlayer].Name
+	if !acted && l.turnTimer != nil && l.turnPlayer == player {
+		return
+	}
+
+	l.stopTurnClock()
+	actionTime := time.Duration(l.texas.Config.ActionTime) * time.Second
+	l.turnPlayer = player
+	l.turnDeadline = time.Now().Add(actionTime)
+	l.startTurnTimer(actionTime)
+}
+
+// startTurnTimer fires the turn timer after the duration, older timers are ignored.
+func (l *lobby) startTurnTimer(duration time.Duration) {
+	l.turnSeq++
+	seq := l.turnSeq
+	l.turnTimer = time.AfterFunc(duration, func() {
+		l.turnExpired(seq)
+	})
+}
+
+// stopTurnClock stops the clock, the time bank spent so far is taken from the player.
+func (l *lobby) stopTurnClock() {
+	if l.turnTimer == nil {
+		return
+	}
+
+	l.turnTimer.Stop()
+	l.turnTimer = nil
+	l.turnSeq++
+	if !l.bankStarted.IsZero() {
+		l.spendTimeBank(time.Since(l.bankStarted))
+	}
+
+	l.turnPlayer = ""
+}
+
+// turnExpired starts the time bank of the player to act if they have one left,
+// otherwise the engine acts for them.
+func (l *lobby) turnExpired(seq int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if seq != l.turnSeq {
+		// The player has acted in the meantime
+		return
+	}
+
+	if _, ok := l.srv.games.load(l.uuid); !ok {
+		// The game has already been deleted
+		return
+	}
+
+	l.turnTimer = nil
+	if l.bankStarted.IsZero() {
+		if bank := l.timeBank(l.turnPlayer); bank > 0 {
+			log.Printf("[%s] %s is using their time bank", l.uuid[:10], l.turnPlayer)
+			l.bankStarted = time.Now()
+			l.turnDeadline = l.bankStarted.Add(bank)
+			l.startTurnTimer(bank)
+			l.broadcast()
+			return
+		}
+	} else {
+		l.spendTimeBank(l.timeBank(l.turnPlayer))
+	}
+
+	action, err := l.texas.Timeout()
+	if err != nil {
+		log.Printf("[%s] Cannot act for %s: %s", l.uuid[:10], l.turnPlayer, err)
+		return
+	}
+
+	log.Printf("[%s] %s ran out of time, acting %s", l.uuid[:10], l.turnPlayer, action)
+	l.updateTurnClock(true)
+	l.broadcast()
+	l.scheduleNextHand()
+}
+
+// timeBank returns the time bank the player has left.
+func (l *lobby) timeBank(player string) time.Duration {
+	bank, ok := l.timeBanks[player]
+	if !ok {
+		return time.Duration(l.texas.Config.TimeBank) * time.Second
+	}
+
+	return bank
+}
+
+// spendTimeBank takes the time from the time bank of the player to act.
+func (l *lobby) spendTimeBank(spent time.Duration) {
+	bank := l.timeBank(l.turnPlayer) - spent
+	if bank < 0 {
+		bank = 0
+	}
+
+	l.timeBanks[l.turnPlayer] = bank
+	l.bankStarted = time.Time{}
+}
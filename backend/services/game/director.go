@@ -0,0 +1,538 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/TypicalAM/gopoker/models"
+	"github.com/TypicalAM/gopoker/texas"
+	"github.com/google/uuid"
+)
+
+var NotEnoughEntrantsErr = errors.New("Not enough entrants")
+
+// Director runs a multi-table tournament over several lobbies. The tables report
+// their finished hands, the director eliminates the busted players, breaks and
+// balances the tables and deals the next hands. Near the money every table waits
+// for the others to finish the hand before dealing the next one.
+type Director struct {
+	srv    *Server
+	uuid   string
+	config texas.TableConfig
+	mutex  sync.Mutex
+
+	// The running tables and the IDs of their game models
+	tables  []*lobby
+	gameIDs map[string]uint
+
+	// The table each player is seated at
+	seats      map[string]*lobby
+	seatsMutex sync.RWMutex
+
+	entrants   int
+	eliminated []string
+	level      int
+	levelTimer *time.Timer
+
+	// The tables which finished the hand during hand-for-hand play and
+	// the tables waiting for players because they can't deal a hand
+	handForHand bool
+	finished    map[*lobby]bool
+	idle        map[*lobby]bool
+	over        bool
+}
+
+// EnterTournament registers the user for the next multi-table tournament, it starts once
+// the entrants have registered. It returns true if the tournament of the user has started.
+func (srv *Server) EnterTournament(user *models.User, config texas.TableConfig, entrants int) (bool, error) {
+	srv.registrationMutex.Lock()
+	defer srv.registrationMutex.Unlock()
+
+	for _, other := range srv.registered {
+		if other.ID == user.ID {
+			return false, nil
+		}
+	}
+
+	srv.registered = append(srv.registered, user)
+	if len(srv.registered) < entrants {
+		return false, nil
+	}
+
+	// The buy-ins are refunded if the tournament can't start, the users have to register again
+	users := srv.registered
+	srv.registered = nil
+	if _, err := srv.StartTournament(config, users); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Registered returns the number of users waiting for the next multi-table tournament.
+func (srv *Server) Registered() int {
+	srv.registrationMutex.Lock()
+	defer srv.registrationMutex.Unlock()
+
+	return len(srv.registered)
+}
+
+// StartTournament charges the buy-ins, seats the users at as few tables as possible and deals the first hands.
+func (srv *Server) StartTournament(config texas.TableConfig, users []*models.User) (*Director, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !config.IsTournament() {
+		return nil, fmt.Errorf("%w: a tournament needs a buy-in", texas.InvalidConfigErr)
+	}
+
+	if len(users) < 2 {
+		return nil, NotEnoughEntrantsErr
+	}
+
+	d := &Director{
+		srv:      srv,
+		uuid:     uuid.New().String(),
+		config:   config,
+		gameIDs:  make(map[string]uint),
+		seats:    make(map[string]*lobby),
+		entrants: len(users),
+		finished: make(map[*lobby]bool),
+		idle:     make(map[*lobby]bool),
+	}
+
+	for i, user := range users {
+		if err := srv.payEntry(user, d.uuid, config.BuyIn); err != nil {
+			for _, paid := range users[:i] {
+				srv.refundEntry(paid, d.uuid, config.BuyIn)
+			}
+
+			return nil, fmt.Errorf("cannot buy in for %s: %w", user.Username, err)
+		}
+	}
+
+	// Deal the players around the tables so they are balanced from the start
+	tableCount := (len(users) + config.MaxPlayers - 1) / config.MaxPlayers
+	players := make([][]models.User, tableCount)
+	for i, user := range users {
+		players[i%tableCount] = append(players[i%tableCount], *user)
+	}
+
+	for _, tablePlayers := range players {
+		game := models.Game{
+			UUID:    uuid.New().String(),
+			Players: tablePlayers,
+		}
+		game.SetTableConfig(config)
+
+		if res := srv.db.Create(&game); res.Error != nil {
+			d.cancel(users)
+			return nil, res.Error
+		}
+
+		d.gameIDs[game.UUID] = game.ID
+		table := newTournamentLobby(srv, game.UUID, config, d)
+		for _, player := range tablePlayers {
+			if err := table.texas.AddPlayer(player.Username, config.StartingStack); err != nil {
+				d.cancel(users)
+				return nil, err
+			}
+
+			d.seats[player.Username] = table
+		}
+
+		d.tables = append(d.tables, table)
+	}
+
+	// The tables are only saved once every player is seated, so nobody joins a tournament which is cancelled
+	for _, table := range d.tables {
+		srv.games.save(table)
+	}
+
+	log.Printf("[%s] Starting a tournament of %d players at %d tables", d.uuid[:10], len(users), tableCount)
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, table := range d.tables {
+		table.mutex.Lock()
+		table.startGame()
+		table.mutex.Unlock()
+	}
+
+	d.scheduleLevel()
+	return d, nil
+}
+
+// cancel refunds the buy-ins of the tournament which couldn't start and deletes its tables.
+func (d *Director) cancel(users []*models.User) {
+	for _, user := range users {
+		d.srv.refundEntry(user, d.uuid, d.config.BuyIn)
+		d.srv.releaseUser(user)
+	}
+
+	for uuid := range d.gameIDs {
+		d.srv.deleteGame(uuid)
+	}
+}
+
+// Standings returns the players in their finishing order, the ones still playing come first.
+func (d *Director) Standings() []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	standings := []string{}
+	for _, table := range d.tables {
+		table.mutex.Lock()
+		standings = append(standings, table.texas.Remaining()...)
+		table.mutex.Unlock()
+	}
+
+	for i := len(d.eliminated) - 1; i >= 0; i-- {
+		standings = append(standings, d.eliminated[i])
+	}
+
+	return standings
+}
+
+// tableOf returns the table the player is seated at.
+func (d *Director) tableOf(username string) (*lobby, bool) {
+	d.seatsMutex.RLock()
+	defer d.seatsMutex.RUnlock()
+
+	table, ok := d.seats[username]
+	return table, ok
+}
+
+// handFinished is called by a table once its hand is over, it must not be called with the table locked.
+func (d *Director) handFinished(table *lobby) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	table.mutex.Lock()
+	busted := table.texas.EliminatePlayers()
+	table.mutex.Unlock()
+
+	d.eliminate(busted)
+	d.dropDeletedTables()
+	if d.remaining() > 1 {
+		d.balance(table)
+	}
+
+	d.updateHandForHand()
+	if !d.isRunning(table) {
+		// The table has been broken
+		return
+	}
+
+	table.mutex.Lock()
+	players := len(table.texas.Remaining())
+	table.mutex.Unlock()
+
+	if players < 2 && !d.over {
+		log.Printf("[%s] Table %s is waiting for players", d.uuid[:10], table.uuid[:10])
+		d.idle[table] = true
+		return
+	}
+
+	if !d.handForHand {
+		d.resume(table)
+		return
+	}
+
+	// Hand-for-hand, the next hands are dealt once every table is done
+	d.finished[table] = true
+	for _, other := range d.tables {
+		if !d.finished[other] && !d.idle[other] {
+			log.Printf("[%s] Hand-for-hand, waiting for the other tables", d.uuid[:10])
+			return
+		}
+	}
+
+	for _, other := range d.tables {
+		if d.finished[other] {
+			delete(d.finished, other)
+			d.resume(other)
+		}
+	}
+}
+
+// eliminate records the busted players, the last one standing wins the tournament.
+func (d *Director) eliminate(busted []string) {
+	d.seatsMutex.Lock()
+	for _, name := range busted {
+		log.Printf("[%s] %s is eliminated in place %d", d.uuid[:10], name, d.entrants-len(d.eliminated))
+		d.eliminated = append(d.eliminated, name)
+		delete(d.seats, name)
+	}
+	d.seatsMutex.Unlock()
+
+	if d.over || d.remaining() > 1 {
+		return
+	}
+
+	d.over = true
+	if d.levelTimer != nil {
+		d.levelTimer.Stop()
+	}
+
+	d.payPrizes()
+}
+
+// dropDeletedTables forgets the tables deleted by the server, the players left there are eliminated.
+func (d *Director) dropDeletedTables() {
+	tables := []*lobby{}
+	for _, table := range d.tables {
+		if _, ok := d.srv.games.load(table.uuid); ok {
+			tables = append(tables, table)
+			continue
+		}
+
+		left := []string{}
+		d.seatsMutex.RLock()
+		for name, seat := range d.seats {
+			if seat == table {
+				left = append(left, name)
+			}
+		}
+		d.seatsMutex.RUnlock()
+
+		sort.Strings(left)
+		delete(d.finished, table)
+		delete(d.idle, table)
+		d.eliminate(left)
+	}
+
+	d.tables = tables
+}
+
+// balance breaks the table if the players fit at the other tables, otherwise it
+// moves the players to the shortest tables until they differ by at most one player.
+// Only the table between hands loses players, the others get them for the next hand.
+func (d *Director) balance(table *lobby) {
+	if len(d.tables) < 2 {
+		return
+	}
+
+	counts := make(map[*lobby]int, len(d.tables))
+	total := 0
+	for _, other := range d.tables {
+		other.mutex.Lock()
+		counts[other] = len(other.texas.Remaining())
+		other.mutex.Unlock()
+		total += counts[other]
+	}
+
+	shortest := func() *lobby {
+		var best *lobby
+		for _, other := range d.tables {
+			if other != table && (best == nil || counts[other] < counts[best]) {
+				best = other
+			}
+		}
+
+		return best
+	}
+
+	smallest := true
+	for _, other := range d.tables {
+		if counts[other] < counts[table] {
+			smallest = false
+		}
+	}
+
+	if smallest && total <= (len(d.tables)-1)*d.config.MaxPlayers {
+		log.Printf("[%s] Breaking table %s", d.uuid[:10], table.uuid[:10])
+		for counts[table] > 0 {
+			target := shortest()
+			if !d.move(table, target) {
+				break
+			}
+
+			counts[table]--
+			counts[target]++
+		}
+
+		// The players who couldn't be moved keep playing here, the table is broken after a later hand
+		if counts[table] == 0 {
+			d.breakTable(table)
+		}
+
+		return
+	}
+
+	for target := shortest(); counts[table]-counts[target] >= 2; target = shortest() {
+		if !d.move(table, target) {
+			return
+		}
+
+		counts[table]--
+		counts[target]++
+	}
+}
+
+// move moves the player who would post the next big blind to the other table.
+func (d *Director) move(from *lobby, to *lobby) bool {
+	from.mutex.Lock()
+	name := from.texas.NextBigBlind()
+	stack, err := from.texas.TransferOut(name)
+	if err != nil {
+		from.mutex.Unlock()
+		log.Printf("[%s] Cannot move %s from table %s: %s", d.uuid[:10], name, from.uuid[:10], err)
+		return false
+	}
+
+	from.mutex.Unlock()
+
+	to.mutex.Lock()
+	if err := to.texas.TransferIn(name, stack); err != nil {
+		to.mutex.Unlock()
+		log.Printf("[%s] Cannot seat %s at table %s: %s", d.uuid[:10], name, to.uuid[:10], err)
+
+		// The player goes back to their seat
+		from.mutex.Lock()
+		if err := from.texas.TransferIn(name, stack); err != nil {
+			log.Printf("[%s] Cannot seat %s back at table %s: %s", d.uuid[:10], name, from.uuid[:10], err)
+		}
+		from.broadcast()
+		from.mutex.Unlock()
+		return false
+	}
+	to.broadcast()
+	to.mutex.Unlock()
+
+	// The client is only told to move once they have a seat at the other table
+	from.mutex.Lock()
+	from.moveClient(name, to.uuid)
+	from.broadcast()
+	from.mutex.Unlock()
+
+	d.seatsMutex.Lock()
+	d.seats[name] = to
+	d.seatsMutex.Unlock()
+
+	d.srv.assignUser(name, d.gameIDs[to.uuid])
+	log.Printf("[%s] Moved %s with %d chips to table %s", d.uuid[:10], name, stack, to.uuid[:10])
+
+	// A table waiting for players can deal again, unless the others are playing hand-for-hand
+	if d.idle[to] && !d.handForHand {
+		delete(d.idle, to)
+		d.resume(to)
+	} else if d.idle[to] {
+		delete(d.idle, to)
+		d.finished[to] = true
+	}
+
+	return true
+}
+
+// breakTable closes the table whose players have been moved.
+func (d *Director) breakTable(table *lobby) {
+	for i, other := range d.tables {
+		if other == table {
+			d.tables = append(d.tables[:i], d.tables[i+1:]...)
+			break
+		}
+	}
+
+	delete(d.finished, table)
+	delete(d.idle, table)
+
+	table.mutex.Lock()
+	table.shutdown()
+	table.mutex.Unlock()
+}
+
+// updateHandForHand starts hand-for-hand play on the bubble, when the next player out doesn't get paid.
+func (d *Director) updateHandForHand() {
+	paid := len(texas.PayoutTable(d.entrants))
+	bubble := len(d.tables) > 1 && d.remaining() == paid+1
+	if bubble != d.handForHand {
+		log.Printf("[%s] Hand-for-hand play: %v", d.uuid[:10], bubble)
+	}
+
+	d.handForHand = bubble
+	if !bubble {
+		for table := range d.finished {
+			delete(d.finished, table)
+			d.resume(table)
+		}
+	}
+}
+
+// resume deals the next hand at the table.
+func (d *Director) resume(table *lobby) {
+	table.mutex.Lock()
+	defer table.mutex.Unlock()
+
+	table.handReported = false
+	table.dealNextHand()
+}
+
+// scheduleLevel raises the blinds at every table once the level is over.
+func (d *Director) scheduleLevel() {
+	d.levelTimer = time.AfterFunc(time.Duration(d.config.LevelMinutes)*time.Minute, d.raiseBlinds)
+}
+
+// raiseBlinds moves every table to the next blind level.
+func (d *Director) raiseBlinds() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.over {
+		return
+	}
+
+	d.level++
+	for _, table := range d.tables {
+		table.mutex.Lock()
+		table.texas.SetLevel(d.level)
+		table.broadcast()
+		table.mutex.Unlock()
+	}
+
+	log.Printf("[%s] The blinds go up to level %d", d.uuid[:10], d.level+1)
+	d.scheduleLevel()
+}
+
+// payPrizes pays the prize pool to the paid places.
+func (d *Director) payPrizes() {
+	standings := []string{}
+	d.seatsMutex.RLock()
+	for name := range d.seats {
+		standings = append(standings, name)
+	}
+	d.seatsMutex.RUnlock()
+
+	for i := len(d.eliminated) - 1; i >= 0; i-- {
+		standings = append(standings, d.eliminated[i])
+	}
+
+	for _, payout := range texas.SplitPrizePool(d.config.BuyIn*d.entrants, d.entrants, standings) {
+		log.Printf("[%s] %s finished in place %d and wins %d", d.uuid[:10], payout.Name, payout.Place, payout.Amount)
+		d.srv.payPrize(payout.Name, d.uuid, payout.Amount)
+	}
+}
+
+// isRunning checks if the table is still a part of the tournament.
+func (d *Director) isRunning(table *lobby) bool {
+	for _, other := range d.tables {
+		if other == table {
+			return true
+		}
+	}
+
+	return false
+}
+
+// remaining counts the players still in the tournament.
+func (d *Director) remaining() int {
+	d.seatsMutex.RLock()
+	defer d.seatsMutex.RUnlock()
+
+	return len(d.seats)
+}
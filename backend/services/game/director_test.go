@@ -0,0 +1,364 @@
+package game
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/TypicalAM/gopoker/config"
+	"github.com/TypicalAM/gopoker/models"
+	"github.com/TypicalAM/gopoker/texas"
+	"gorm.io/gorm"
+)
+
+var directorDB *gorm.DB
+var directorDBErr error
+var directorDBOnce sync.Once
+
+// newTestServer creates a game server on the test database, the tables are migrated by TestMain.
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	directorDBOnce.Do(func() {
+		directorDB, directorDBErr = models.New(config.New())
+	})
+
+	if directorDBErr != nil {
+		t.Fatalf("cannot connect to the database: %s", directorDBErr)
+	}
+
+	cfg := config.New()
+	cfg.SpectatorDelay = 0
+	cfg.BotThinkMillis = 0
+	cfg.TournamentFee = 10
+	return New(directorDB, cfg)
+}
+
+// startTestTournament creates the entrants and starts a tournament with the tables of the size.
+func startTestTournament(t *testing.T, prefix string, entrants int, tableSize int) (*Server, *Director) {
+	t.Helper()
+
+	srv := newTestServer(t)
+	users := make([]*models.User, entrants)
+	for i := range users {
+		users[i] = &models.User{Username: fmt.Sprintf("%s%d", prefix, i), Password: "testpass"}
+		if res := srv.db.Create(users[i]); res.Error != nil {
+			t.Fatalf("cannot create the user: %s", res.Error)
+		}
+	}
+
+	t.Cleanup(func() {
+		srv.db.Delete(&models.User{}, "username LIKE ?", prefix+"%")
+	})
+
+	tableConfig := texas.TableConfig{
+		SmallBlind:    10,
+		BigBlind:      20,
+		StartingStack: 1500,
+		MinBuyIn:      1500,
+		MaxBuyIn:      1500,
+		MinPlayers:    texas.MinimumPlayers,
+		MaxPlayers:    tableSize,
+		Structure:     texas.NoLimit,
+		BuyIn:         100,
+		LevelMinutes:  60,
+	}
+
+	d, err := srv.StartTournament(tableConfig, users)
+	if err != nil {
+		t.Fatalf("cannot start the tournament: %s", err)
+	}
+
+	t.Cleanup(func() {
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+
+		d.levelTimer.Stop()
+		for _, table := range d.tables {
+			table.mutex.Lock()
+			table.shutdown()
+			table.mutex.Unlock()
+		}
+	})
+
+	return srv, d
+}
+
+// finishHand folds the hand at the table to the last player, busts the first players
+// still in the tournament and reports the hand to the director.
+func finishHand(t *testing.T, d *Director, table *lobby, busted int) []string {
+	t.Helper()
+
+	table.mutex.Lock()
+	for !table.texas.IsHandOver() {
+		current := table.texas.Players[table.texas.CurrentPlayer].Name
+		if err := table.texas.AdvanceState(current, texas.Fold, 0); err != nil {
+			table.mutex.Unlock()
+			t.Fatalf("%s cannot fold: %s", current, err)
+		}
+	}
+
+	names := table.texas.Remaining()[:busted]
+	for i := range table.texas.Players {
+		for _, name := range names {
+			if table.texas.Players[i].Name == name {
+				table.texas.Players[i].Assets = 0
+			}
+		}
+	}
+
+	table.handReported = true
+	table.mutex.Unlock()
+
+	d.handFinished(table)
+	return names
+}
+
+// seated returns the number of players still in the tournament at the table.
+func seated(table *lobby) int {
+	table.mutex.Lock()
+	defer table.mutex.Unlock()
+
+	return len(table.texas.Remaining())
+}
+
+// waiting returns true if the table waits for the others before dealing the next hand.
+func waiting(table *lobby) bool {
+	table.mutex.Lock()
+	defer table.mutex.Unlock()
+
+	return table.nextHandTimer == nil
+}
+
+func TestDirectorBalance(t *testing.T) {
+	_, d := startTestTournament(t, "mttbalance", 8, 4)
+	if len(d.tables) != 2 {
+		t.Fatalf("expected 2 tables, got %d", len(d.tables))
+	}
+
+	first, second := d.tables[0], d.tables[1]
+	finishHand(t, d, first, 2)
+	if seated(first) != 2 || seated(second) != 4 {
+		t.Fatalf("only the table between hands loses players, got %d and %d", seated(first), seated(second))
+	}
+
+	finishHand(t, d, second, 0)
+	if seated(first) != 3 || seated(second) != 3 {
+		t.Fatalf("expected the tables to be balanced, got %d and %d", seated(first), seated(second))
+	}
+
+	for i := 0; i < 8; i++ {
+		name := fmt.Sprintf("mttbalance%d", i)
+		table, ok := d.tableOf(name)
+		if !ok {
+			continue
+		}
+
+		table.mutex.Lock()
+		_, found := table.texas.FindPlayer(name)
+		table.mutex.Unlock()
+		if !found {
+			t.Errorf("%s is not seated at their table", name)
+		}
+	}
+}
+
+func TestDirectorBreakTable(t *testing.T) {
+	srv, d := startTestTournament(t, "mttbreak", 9, 4)
+	if len(d.tables) != 3 {
+		t.Fatalf("expected 3 tables, got %d", len(d.tables))
+	}
+
+	broken := d.tables[0]
+	finishHand(t, d, broken, 2)
+	if len(d.tables) != 2 || d.isRunning(broken) {
+		t.Fatalf("expected the table to be broken, %d tables are running", len(d.tables))
+	}
+
+	if _, ok := srv.games.load(broken.uuid); ok {
+		t.Errorf("the broken table is still saved")
+	}
+
+	total := 0
+	for _, table := range d.tables {
+		total += seated(table)
+	}
+
+	if total != 7 || d.remaining() != 7 {
+		t.Errorf("expected 7 players at the other tables, got %d and %d", total, d.remaining())
+	}
+}
+
+func TestDirectorBreakTableFailedMove(t *testing.T) {
+	_, d := startTestTournament(t, "mttfailed", 9, 4)
+	if len(d.tables) != 3 {
+		t.Fatalf("expected 3 tables, got %d", len(d.tables))
+	}
+
+	// The other tables can't take anyone, so the player left can't be moved
+	broken := d.tables[0]
+	for _, table := range d.tables[1:] {
+		table.mutex.Lock()
+		table.texas.Config.MaxPlayers = 3
+		table.mutex.Unlock()
+	}
+
+	finishHand(t, d, broken, 2)
+	if !d.isRunning(broken) || seated(broken) != 1 {
+		t.Fatalf("expected the table to keep its player, %d tables are running", len(d.tables))
+	}
+
+	broken.mutex.Lock()
+	name := broken.texas.Remaining()[0]
+	broken.mutex.Unlock()
+	if table, ok := d.tableOf(name); !ok || table != broken {
+		t.Errorf("expected %s to stay at their table", name)
+	}
+
+	for _, table := range d.tables[1:] {
+		table.mutex.Lock()
+		table.texas.Config.MaxPlayers = 4
+		table.mutex.Unlock()
+	}
+
+	finishHand(t, d, broken, 0)
+	if len(d.tables) != 2 || d.isRunning(broken) {
+		t.Fatalf("expected the table to be broken once the player can move, %d tables are running", len(d.tables))
+	}
+
+	if table, ok := d.tableOf(name); !ok || table == broken {
+		t.Errorf("expected %s to be moved to another table", name)
+	}
+}
+
+func TestDirectorHandForHand(t *testing.T) {
+	_, d := startTestTournament(t, "mtthand", 12, 4)
+	if len(d.tables) != 3 {
+		t.Fatalf("expected 3 tables, got %d", len(d.tables))
+	}
+
+	first, second, third := d.tables[0], d.tables[1], d.tables[2]
+	finishHand(t, d, first, 2)
+	finishHand(t, d, second, 2)
+	if len(d.tables) != 2 || d.handForHand {
+		t.Fatalf("expected the second table to be broken before the bubble")
+	}
+
+	// Six players are left and five get paid
+	finishHand(t, d, third, 2)
+	if !d.handForHand {
+		t.Fatalf("expected hand-for-hand play on the bubble")
+	}
+
+	if !waiting(third) {
+		t.Errorf("the table dealt the next hand before the other table finished")
+	}
+
+	finishHand(t, d, first, 0)
+	if waiting(first) || waiting(third) {
+		t.Errorf("expected both tables to deal the next hand")
+	}
+
+	if seated(first) != 3 || seated(third) != 3 {
+		t.Errorf("expected the tables to be balanced, got %d and %d", seated(first), seated(third))
+	}
+}
+
+func TestDirectorPayPrizes(t *testing.T) {
+	srv, d := startTestTournament(t, "mttprize", 3, 3)
+	table := d.tables[0]
+	busted := finishHand(t, d, table, 2)
+	if !d.over {
+		t.Fatalf("expected the tournament to be over")
+	}
+
+	standings := d.Standings()
+	if len(standings) != 3 || standings[0] == busted[0] || standings[0] == busted[1] {
+		t.Fatalf("unexpected standings %v", standings)
+	}
+
+	// The winner takes the whole prize pool of three buy-ins, the fees are kept as the rake
+	for i, name := range standings {
+		var user models.User
+		if res := srv.db.First(&user, "username = ?", name); res.Error != nil {
+			t.Fatalf("cannot find %s: %s", name, res.Error)
+		}
+
+		wallet, err := models.GetWallet(srv.db, user.ID)
+		if err != nil {
+			t.Fatalf("cannot get the wallet of %s: %s", name, err)
+		}
+
+		expected := models.StartingBalance - 110
+		if i == 0 {
+			expected += 300
+		}
+
+		if wallet.Balance != expected {
+			t.Errorf("expected %s to have %d chips, got %d", name, expected, wallet.Balance)
+		}
+	}
+}
+
+func TestEnterTournament(t *testing.T) {
+	srv := newTestServer(t)
+	users := make([]*models.User, 3)
+	for i := range users {
+		users[i] = &models.User{Username: fmt.Sprintf("mttenter%d", i), Password: "testpass"}
+		if res := srv.db.Create(users[i]); res.Error != nil {
+			t.Fatalf("cannot create the user: %s", res.Error)
+		}
+	}
+
+	t.Cleanup(func() {
+		for _, user := range users {
+			var reloaded models.User
+			if res := srv.db.First(&reloaded, "id = ?", user.ID); res.Error == nil && reloaded.GameID != nil {
+				srv.db.Delete(&models.Game{}, "id = ?", *reloaded.GameID)
+			}
+		}
+
+		srv.db.Delete(&models.User{}, "username LIKE ?", "mttenter%")
+	})
+
+	tableConfig := texas.TableConfig{
+		SmallBlind:    10,
+		BigBlind:      20,
+		StartingStack: 1500,
+		MinBuyIn:      1500,
+		MaxBuyIn:      1500,
+		MinPlayers:    texas.MinimumPlayers,
+		MaxPlayers:    2,
+		Structure:     texas.NoLimit,
+		BuyIn:         100,
+		LevelMinutes:  60,
+	}
+
+	for i, user := range users {
+		started, err := srv.EnterTournament(user, tableConfig, len(users))
+		if err != nil {
+			t.Fatalf("cannot enter the tournament: %s", err)
+		}
+
+		if started != (i == len(users)-1) {
+			t.Fatalf("expected the tournament to start with the last entrant, started after %d", i+1)
+		}
+
+		if i == 0 {
+			if started, _ := srv.EnterTournament(user, tableConfig, len(users)); started || srv.Registered() != 1 {
+				t.Fatalf("expected the second registration to be ignored")
+			}
+		}
+	}
+
+	if srv.Registered() != 0 {
+		t.Errorf("expected the registrations to be cleared, got %d", srv.Registered())
+	}
+
+	for _, user := range users {
+		var reloaded models.User
+		if res := srv.db.First(&reloaded, "id = ?", user.ID); res.Error != nil || reloaded.GameID == nil {
+			t.Errorf("expected %s to be seated at a table", user.Username)
+		}
+	}
+}
@@ -1,31 +1,72 @@
 package game
 
 import (
+	"errors"
 	"log"
+	"sync"
+	"time"
 
+	"github.com/TypicalAM/gopoker/config"
 	"github.com/TypicalAM/gopoker/models"
+	"github.com/TypicalAM/gopoker/services/bot"
+	"github.com/TypicalAM/gopoker/texas"
 	"github.com/gorilla/websocket"
 	"gorm.io/gorm"
 )
 
+// NoSuchGameErr is returned when the game isn't running on the server.
+var NoSuchGameErr = errors.New("no such game")
+
 // Server maintains the set of active clients and lobbies.
 type Server struct {
 	db              *gorm.DB
 	games           gameStore
 	registerQueue   chan *Client
 	unregisterQueue chan *Client
+	spectatorDelay  time.Duration
+	chatFilter      ChatFilter
+
+	// The strategy of the bots filling the public tables, the time they think
+	// about a move and the time a table waits for players before they sit down
+	botStrategy bot.Strategy
+	botThink    time.Duration
+	botBackfill time.Duration
+
+	// The percentage of the tournament buy-in charged as the rake
+	tournamentFee int
+
+	// The users registered for the next multi-table tournament
+	registered        []*models.User
+	registrationMutex sync.Mutex
 }
 
 // New creates a new game server.
-func New(db *gorm.DB) *Server {
+func New(db *gorm.DB, cfg *config.Config) *Server {
+	strategy, err := bot.Open(cfg.BotStrategy, time.Duration(cfg.BotTimeoutMillis)*time.Millisecond)
+	if err != nil {
+		log.Printf("Invalid bot strategy, using %s: %s", bot.TightAggressiveName, err)
+		strategy = bot.TightAggressive{}
+	}
+
 	return &Server{
 		db:              db,
 		games:           newGameStore(),
 		registerQueue:   make(chan *Client),
 		unregisterQueue: make(chan *Client),
+		spectatorDelay:  time.Duration(cfg.SpectatorDelay) * time.Second,
+		chatFilter:      WordFilter(cfg.ChatBannedWords),
+		botStrategy:     strategy,
+		botThink:        time.Duration(cfg.BotThinkMillis) * time.Millisecond,
+		botBackfill:     time.Duration(cfg.BotBackfillSeconds) * time.Second,
+		tournamentFee:   cfg.TournamentFee,
 	}
 }
 
+// SetChatFilter replaces the filter the chat messages go through, it must be called before Run.
+func (srv *Server) SetChatFilter(filter ChatFilter) {
+	srv.chatFilter = filter
+}
+
 // Run starts the game server.
 func (srv *Server) Run() {
 	for {
@@ -43,7 +84,11 @@ func (srv *Server) Run() {
 func (srv *Server) Connect(conn *websocket.Conn, game *models.Game, user *models.User) {
 	lobby, ok := srv.games.load(game.UUID)
 	if !ok {
-		lobby = newLobby(srv, game.UUID)
+		lobby = newLobby(srv, game.UUID, game.TableConfig())
+		if !game.Private {
+			lobby.backfillWait = srv.botBackfill
+		}
+
 		srv.games.save(lobby)
 	}
 
@@ -53,9 +98,45 @@ func (srv *Server) Connect(conn *websocket.Conn, game *models.Game, user *models
 	go client.readLoop()
 }
 
+// Watch creates a new spectator client and enqueues it for registration.
+func (srv *Server) Watch(conn *websocket.Conn, uuid string, user *models.User) error {
+	lobby, ok := srv.games.load(uuid)
+	if !ok {
+		return NoSuchGameErr
+	}
+
+	client := newClient(srv, lobby, conn, user)
+	client.spectator = true
+	srv.registerQueue <- client
+	go client.writeLoop()
+	go client.readLoop()
+	return nil
+}
+
+// Kick removes the player from a game which hasn't started yet, it does nothing if the game isn't running.
+func (srv *Server) Kick(uuid string, username string) error {
+	lobby, ok := srv.games.load(uuid)
+	if !ok {
+		return nil
+	}
+
+	return lobby.kick(username)
+}
+
+// IsRunning returns true if the game is running on the server.
+func (srv *Server) IsRunning(uuid string) bool {
+	_, ok := srv.games.load(uuid)
+	return ok
+}
+
 // register registers a client with the game server and assigns it to a lobby.
 func (srv *Server) register(client *Client) {
 	log.Printf("[%s] Registering client", client.user.Username)
+	if client.spectator {
+		client.lobby.addSpectator(client)
+		return
+	}
+
 	client.lobby.addClient(client)
 }
 
@@ -64,23 +145,10 @@ func (srv *Server) register(client *Client) {
 func (srv *Server) unregisterClient(client *Client) {
 	log.Printf("[%s] Unregistering client", client.user.Username)
 	client.conn.Close()
-	close(client.send)
 
-	game, ok := srv.games.load(client.lobby.uuid)
-	if !ok {
-		// The game has already been deleted
-		return
-	}
-
-	if err := game.disconnect(client); err != nil {
-		log.Printf("[%s] Error disconnecting client: %s", client.lobby.uuid[:10], err)
-		return
-	}
-
-	if game.isEmpty() {
-		log.Printf("[%s] Deleting game", client.lobby.uuid[:10])
-		srv.deleteGame(client.lobby.uuid)
-	}
+	// The lobby mustn't send anything to the client once its channel is closed
+	client.lobby.unregister(client)
+	close(client.send)
 }
 
 // startGame starts a game.
@@ -91,7 +159,7 @@ func (srv *Server) startGame(uuid string) {
 	}
 }
 
-// deleteGame deletes a game.
+// deleteGame deletes a game from the store and the database, the lobby calls it from shutdown.
 func (srv *Server) deleteGame(uuid string) {
 	srv.games.delete(uuid)
 	if res := srv.db.Delete(&models.Game{}, "uuid = ?", uuid); res.Error != nil {
@@ -99,3 +167,108 @@ func (srv *Server) deleteGame(uuid string) {
 	}
 	log.Printf("[%s] Ended & Deleted", uuid[:10])
 }
+
+// releaseUser removes the user from their game, so they can join another one.
+func (srv *Server) releaseUser(user *models.User) {
+	if res := srv.db.Model(&models.User{}).Where("id = ?", user.ID).Update("game_id", nil); res.Error != nil {
+		log.Printf("[%s] Error releasing the user from their game: %s", user.Username, res.Error)
+	}
+}
+
+// assignUser moves the user to another game, the tournament director uses it when moving players between tables.
+func (srv *Server) assignUser(username string, gameID uint) {
+	if res := srv.db.Model(&models.User{}).Where("username = ?", username).Update("game_id", gameID); res.Error != nil {
+		log.Printf("[%s] Error moving the user to game %d: %s", username, gameID, res.Error)
+	}
+}
+
+// buyIn takes the chips for a seat from the user's wallet.
+func (srv *Server) buyIn(user *models.User, uuid string, amount int) error {
+	return models.Transact(srv.db, user.ID, models.LedgerBuyIn, -amount, uuid)
+}
+
+// payEntry takes the tournament buy-in and the fee from the user's wallet, the fee is recorded as the rake.
+func (srv *Server) payEntry(user *models.User, uuid string, buyIn int) error {
+	if err := srv.buyIn(user, uuid, buyIn); err != nil {
+		return err
+	}
+
+	fee := buyIn * srv.tournamentFee / 100
+	if fee == 0 {
+		return nil
+	}
+
+	if err := models.Transact(srv.db, user.ID, models.LedgerRake, -fee, uuid); err != nil {
+		srv.cashOut(user, uuid, buyIn)
+		return err
+	}
+
+	return nil
+}
+
+// refundEntry returns the tournament buy-in and the fee to the user's wallet, the tournament didn't start for them.
+func (srv *Server) refundEntry(user *models.User, uuid string, buyIn int) {
+	srv.cashOut(user, uuid, buyIn)
+
+	fee := buyIn * srv.tournamentFee / 100
+	if fee == 0 {
+		return
+	}
+
+	if err := models.Transact(srv.db, user.ID, models.LedgerRake, fee, uuid); err != nil {
+		log.Printf("[%s] Error refunding the fee of %s: %s", uuid[:10], user.Username, err)
+	}
+}
+
+// topUp takes the chips added to a stack at the table from the user's wallet.
+func (srv *Server) topUp(user *models.User, uuid string, amount int) error {
+	return models.Transact(srv.db, user.ID, models.LedgerTopUp, -amount, uuid)
+}
+
+// cashOut returns the chips left at the table to the user's wallet.
+func (srv *Server) cashOut(user *models.User, uuid string, amount int) {
+	if amount == 0 {
+		return
+	}
+
+	if err := models.Transact(srv.db, user.ID, models.LedgerCashOut, amount, uuid); err != nil {
+		log.Printf("[%s] Error cashing out %s: %s", uuid[:10], user.Username, err)
+	}
+}
+
+// payPrize adds the tournament prize to the user's wallet, the user is looked up by username.
+func (srv *Server) payPrize(username string, uuid string, amount int) {
+	var user models.User
+	if res := srv.db.Where("username = ?", username).First(&user); res.Error != nil {
+		log.Printf("[%s] Error finding %s to pay their prize: %s", uuid[:10], username, res.Error)
+		return
+	}
+
+	if err := models.Transact(srv.db, user.ID, models.LedgerWinnings, amount, uuid); err != nil {
+		log.Printf("[%s] Error paying the prize of %s: %s", uuid[:10], username, err)
+	}
+}
+
+// recordHand saves the history of a finished hand.
+func (srv *Server) recordHand(uuid string, history texas.HandHistory) {
+	names := make([]string, len(history.Seats))
+	for i, seat := range history.Seats {
+		names[i] = seat.Name
+	}
+
+	var users []models.User
+	if res := srv.db.Where("username IN ?", names).Find(&users); res.Error != nil {
+		log.Printf("[%s] Error finding the players of hand %d: %s", uuid[:10], history.Number, res.Error)
+		return
+	}
+
+	userIDs := make(map[string]uint, len(users))
+	for _, user := range users {
+		userIDs[user.Username] = user.ID
+	}
+
+	hand := models.NewHand(uuid, history, userIDs)
+	if res := srv.db.Create(&hand); res.Error != nil {
+		log.Printf("[%s] Error recording hand %d: %s", uuid[:10], history.Number, res.Error)
+	}
+}
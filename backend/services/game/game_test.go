@@ -79,6 +79,8 @@ func TestMain(m *testing.M) {
 type userWS struct {
 	username string
 	conn     *websocket.Conn
+	url      string
+	jar      http.CookieJar
 }
 
 type queueResponse struct {
@@ -149,6 +151,8 @@ func createConnect(t *testing.T) ([]userWS, *httptest.Server) {
 		users[i] = userWS{
 			username: user.Username,
 			conn:     ws,
+			url:      wsURL,
+			jar:      jar,
 		}
 	}
 
@@ -258,6 +262,15 @@ func TestExampleErrors(t *testing.T) {
 				Data: "Invalid",
 			},
 		},
+		{
+			name:      "illegal raise size",
+			userIndex: 0,
+			msg: game.GameMessage{
+				Type:   game.MsgAction,
+				Data:   string(texas.Raise),
+				Amount: 1,
+			},
+		},
 		{
 			name:      "wrong type",
 			userIndex: 0,
@@ -290,3 +303,152 @@ func TestExampleErrors(t *testing.T) {
 func teardown() error {
 	return tdb.Delete(&models.User{}, "username LIKE ?", "user%").Error
 }
+
+// TestReconnect tests that a player who reconnects gets their seat and hand back
+func TestReconnect(t *testing.T) {
+	users, server := createConnect(t)
+	defer func() {
+		server.Close()
+		for _, user := range users {
+			user.conn.Close()
+		}
+	}()
+
+	users[0].conn.Close()
+	time.Sleep(wsConnectTime)
+
+	dialer := websocket.Dialer{Jar: users[0].jar}
+	ws, _, err := dialer.Dial(users[0].url, nil)
+	if err != nil {
+		t.Fatalf("error dialing websocket: %s", err)
+	}
+
+	users[0].conn = ws
+	msg := readMessage(t, users[0])
+	if msg.Type != game.MsgState {
+		t.Fatalf("expected state message, got %s", msg.Type)
+	}
+
+	var state struct {
+		texas.TexasHoldEm
+		Disconnected []string
+	}
+
+	if err := json.Unmarshal([]byte(msg.Data), &state); err != nil {
+		t.Fatalf("error unmarshalling state: %s", err)
+	}
+
+	player, ok := state.FindPlayer(users[0].username)
+	if !ok || player.Left || !player.Active || len(player.HoleCards) != 2 {
+		t.Fatalf("expected the player to keep their hand, got %+v", player)
+	}
+
+	if len(state.Disconnected) != 0 {
+		t.Errorf("expected no held seats, got %v", state.Disconnected)
+	}
+}
+
+// TestChat tests that the chat messages reach the whole table
+func TestChat(t *testing.T) {
+	users, server := createConnect(t)
+	defer func() {
+		server.Close()
+		for _, user := range users {
+			user.conn.Close()
+		}
+	}()
+
+	sendMessage(t, users[0], game.GameMessage{
+		Type: game.MsgChat,
+		Data: "good luck everyone",
+	})
+
+	for _, user := range users {
+		msg := readMessage(t, user)
+		for msg.Type != game.MsgChat {
+			msg = readMessage(t, user)
+		}
+
+		var chatMsg game.ChatMessage
+		if err := json.Unmarshal([]byte(msg.Data), &chatMsg); err != nil {
+			t.Fatalf("error unmarshalling chat message: %s", err)
+		}
+
+		if chatMsg.Username != users[0].username || chatMsg.Text != "good luck everyone" {
+			t.Errorf("expected the chat message of %s, got %+v", users[0].username, chatMsg)
+		}
+	}
+
+	sendMessage(t, users[1], game.GameMessage{
+		Type: game.MsgChat,
+		Data: "   ",
+	})
+
+	msg := readMessage(t, users[1])
+	for msg.Type == game.MsgState || msg.Type == game.MsgChat {
+		msg = readMessage(t, users[1])
+	}
+
+	if msg.Type != game.MsgError {
+		t.Fatalf("expected error message, got %s", msg.Type)
+	}
+}
+
+// TestWordFilter tests masking the banned words in the chat
+func TestWordFilter(t *testing.T) {
+	filter := game.WordFilter([]string{"darn", ""})
+	text, err := filter("Darn it, darned river")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if text != "**** it, darned river" {
+		t.Errorf("expected the banned word to be masked, got %q", text)
+	}
+}
+
+// TestSitOutAndLeave tests sitting out and leaving the table with the protocol messages
+func TestSitOutAndLeave(t *testing.T) {
+	users, server := createConnect(t)
+	defer func() {
+		server.Close()
+		for _, user := range users {
+			user.conn.Close()
+		}
+	}()
+
+	// readPlayer reads the states until the player matches the condition
+	readPlayer := func(user userWS, name string, cond func(texas.Player) bool) {
+		for i := 0; i < 10; i++ {
+			msg := readMessage(t, user)
+			if msg.Type != game.MsgState {
+				continue
+			}
+
+			var state texas.TexasHoldEm
+			if err := json.Unmarshal([]byte(msg.Data), &state); err != nil {
+				t.Fatalf("error unmarshalling state: %s", err)
+			}
+
+			if player, ok := state.FindPlayer(name); ok && cond(player) {
+				return
+			}
+		}
+
+		t.Fatalf("expected the state of %s to change", name)
+	}
+
+	sendMessage(t, users[2], game.GameMessage{
+		Type:   game.MsgSitOut,
+		Amount: 2,
+	})
+
+	readPlayer(users[0], users[2].username, func(player texas.Player) bool {
+		return player.SittingOut && player.SitOutHands == 2
+	})
+
+	sendMessage(t, users[2], game.GameMessage{Type: game.MsgLeave})
+	readPlayer(users[0], users[2].username, func(player texas.Player) bool {
+		return player.Left
+	})
+}
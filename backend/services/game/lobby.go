@@ -4,40 +4,203 @@ import (
 	"encoding/json"
 	"errors"
 	"log"
+	"sync"
+	"time"
 
+	"github.com/TypicalAM/gopoker/models"
+	"github.com/TypicalAM/gopoker/services/bot"
 	"github.com/TypicalAM/gopoker/texas"
 )
 
+// Time between the end of a hand and the start of the next one.
+const nextHandDelay = 5 * time.Second
+
+// Time a disconnected player's seat is held for them to reconnect.
+const reconnectGrace = 30 * time.Second
+
+// heldSeat is the seat of a disconnected player waiting for them to reconnect.
+type heldSeat struct {
+	user  *models.User
+	timer *time.Timer
+}
+
 // lobby represents an instance of a game lobby.
 type lobby struct {
 	srv     *Server
 	uuid    string
 	texas   *texas.TexasHoldEm
 	clients []*Client
+	mutex   sync.Mutex
+
+	// The spectators, the last state they have seen and the delayed states on their way
+	spectators      []*Client
+	spectatorState  string
+	spectatorTimers []*time.Timer
+
+	// The recent chat messages and the times each user has sent their messages
+	chatHistory []ChatMessage
+	chatTimes   map[string][]time.Time
+
+	nextHandTimer *time.Timer
+	held          map[string]*heldSeat
+
+	// The players who left while all-in, their chips are returned once the hand is over
+	leaving map[string]*models.User
+
+	// The clock of the player to act
+	turnTimer    *time.Timer
+	turnSeq      int
+	turnPlayer   string
+	turnDeadline time.Time
+	bankStarted  time.Time
+	timeBanks    map[string]time.Duration
+
+	// The end of the current tournament blind level
+	levelTimer    *time.Timer
+	levelDeadline time.Time
+
+	// The strategies of the bots seated at the table, the bot thinking about its
+	// move and the wait before the empty seats are filled with bots
+	bots          map[string]bot.Strategy
+	botTimer      *time.Timer
+	botSeq        int
+	botPlayer     string
+	backfillWait  time.Duration
+	backfillTimer *time.Timer
+
+	// The director of a multi-table tournament and whether the finished hand has been reported to them
+	director     *Director
+	handReported bool
+
+	// The statistics shown in the table browser
+	startedAt   time.Time
+	handsPlayed int
+	potTotal    int
 }
 
-// newLobby creates a new lobby.
-func newLobby(srv *Server, uuid string) *lobby {
+// newLobby creates a new lobby with the table stakes.
+func newLobby(srv *Server, uuid string, config texas.TableConfig) *lobby {
 	return &lobby{
-		srv:   srv,
-		uuid:  uuid,
-		texas: texas.NewTexasHoldEm(),
+		srv:       srv,
+		uuid:      uuid,
+		texas:     texas.NewTexasHoldEm(config),
+		held:      make(map[string]*heldSeat),
+		leaving:   make(map[string]*models.User),
+		chatTimes: make(map[string][]time.Time),
+		timeBanks: make(map[string]time.Duration),
+		bots:      make(map[string]bot.Strategy),
 	}
 }
 
+// newTournamentLobby creates a table of a multi-table tournament run by the director.
+func newTournamentLobby(srv *Server, uuid string, config texas.TableConfig, d *Director) *lobby {
+	l := newLobby(srv, uuid, config)
+	l.texas = texas.NewTournamentTable(config)
+	l.director = d
+	return l
+}
+
 // addClient adds a client to the game.
 func (l *lobby) addClient(c *Client) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
 	log.Printf("[%s] Adding client %s to the game", l.uuid[:10], c.user.Username)
+
+	// A new connection of the same user replaces the old one
+	for _, client := range l.clients {
+		if client.user.Username == c.user.Username {
+			l.dropClient(client)
+			client.conn.Close()
+			break
+		}
+	}
+
 	l.clients = append(l.clients, c)
+	l.replayChat(c)
 
-	// Let's try adding the client to the game
-	// TODO: Take the chips amount from the user model
-	if err := l.texas.AddPlayer(c.user.Username, 100); err != nil {
-		log.Printf("[%s] Cannot add player to the game: %s", l.uuid[:10], err)
+	// Let's try adding the client to the game, the chips are taken from the wallet
+	player, seated := l.texas.FindPlayer(c.user.Username)
+	switch {
+	case seated && player.Left:
+		l.send(c, &GameMessage{
+			Type: MsgError,
+			Data: "You have left this table, you can join again after the hand",
+		})
+		l.sendState(c)
 		return
+
+	case !seated && l.director != nil:
+		// The players of a tournament are seated by the director
+		if table, ok := l.director.tableOf(c.user.Username); ok && table != l {
+			l.send(c, &GameMessage{
+				Type: MsgMove,
+				Data: table.uuid,
+			})
+		} else {
+			l.send(c, &GameMessage{
+				Type: MsgError,
+				Data: "You are not playing at this table",
+			})
+		}
+
+		l.sendState(c)
+		return
+
+	case seated:
+		if seat, ok := l.held[c.user.Username]; ok {
+			log.Printf("[%s] Client %s reconnected to their seat", l.uuid[:10], c.user.Username)
+			seat.timer.Stop()
+			delete(l.held, c.user.Username)
+		}
+
+	default:
+		// A tournament charges the buy-in and the fee for the starting stack
+		stack, cost := l.texas.Config.StartingStack, l.texas.Config.StartingStack
+		pay, refund := l.srv.buyIn, l.srv.cashOut
+		if l.texas.Config.IsTournament() {
+			cost = l.texas.Config.BuyIn
+			pay, refund = l.srv.payEntry, l.srv.refundEntry
+		}
+
+		if err := pay(c.user, l.uuid, cost); err != nil {
+			log.Printf("[%s] Cannot buy in for %s: %s", l.uuid[:10], c.user.Username, err)
+			l.reject(c, "Cannot buy in: "+err.Error())
+			return
+		}
+
+		if err := l.texas.AddPlayer(c.user.Username, stack); err != nil {
+			log.Printf("[%s] Cannot add player to the game: %s", l.uuid[:10], err)
+			refund(c.user, l.uuid, cost)
+			l.reject(c, "Cannot join the table: "+err.Error())
+			return
+		}
 	}
 
-	// Let's try to start the game
+	// The client gets the current state right away
+	l.sendState(c)
+	l.startGame()
+	l.scheduleBackfill()
+}
+
+// reject tells the client who couldn't get a seat why and closes their connection, the user can join another game.
+func (l *lobby) reject(client *Client, reason string) {
+	l.send(client, &GameMessage{
+		Type: MsgError,
+		Data: reason,
+	})
+
+	l.dropClient(client)
+	l.srv.releaseUser(client.user)
+
+	// Give the client a moment to receive the error before closing the connection
+	time.AfterFunc(writeWait, func() {
+		client.conn.Close()
+	})
+}
+
+// startGame starts the game if enough players are seated.
+func (l *lobby) startGame() {
 	if err := l.texas.StartGame(); err != nil {
 		log.Printf("[%s] Cannot start the game: %s", l.uuid[:10], err)
 		return
@@ -45,6 +208,11 @@ func (l *lobby) addClient(c *Client) {
 
 	// Update the game in the database
 	l.srv.startGame(l.uuid)
+	l.startedAt = time.Now()
+	l.updateTurnClock(true)
+	if l.texas.Config.IsTournament() && l.director == nil {
+		l.scheduleLevel()
+	}
 
 	// Broadcast the game state
 	log.Printf("[%s] Broadcasting game state", l.uuid[:10])
@@ -53,6 +221,17 @@ func (l *lobby) addClient(c *Client) {
 
 // message handles a message from a client.
 func (l *lobby) message(client *Client, gameMsg GameMessage) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if client.spectator && gameMsg.Type != MsgChat {
+		l.send(client, &GameMessage{
+			Type: MsgError,
+			Data: "Spectators cannot act",
+		})
+		return
+	}
+
 	switch gameMsg.Type {
 	case MsgAction:
 		action, ok := texas.DecodeAction(gameMsg.Data)
@@ -64,15 +243,80 @@ func (l *lobby) message(client *Client, gameMsg GameMessage) {
 			return
 		}
 
-		if err := l.texas.AdvanceState(client.user.Username, action); err != nil {
+		if err := l.texas.AdvanceState(client.user.Username, action, gameMsg.Amount); err != nil {
 			l.send(client, &GameMessage{
 				Type: MsgError,
 				Data: err.Error(),
 			})
+		} else {
+			l.updateTurnClock(true)
+		}
+
+		l.broadcast()
+		l.scheduleNextHand()
+
+	case MsgChat:
+		l.chat(client, gameMsg.Data)
+
+	case MsgSeat:
+		if err := l.texas.ChooseSeat(client.user.Username, gameMsg.Amount); err != nil {
+			l.send(client, &GameMessage{
+				Type: MsgError,
+				Data: err.Error(),
+			})
+			return
 		}
 
 		l.broadcast()
 
+	case MsgSitOut:
+		if err := l.texas.SitOut(client.user.Username, gameMsg.Amount); err != nil {
+			l.send(client, &GameMessage{
+				Type: MsgError,
+				Data: err.Error(),
+			})
+			return
+		}
+
+		l.broadcast()
+
+	case MsgSitIn:
+		if err := l.texas.SitIn(client.user.Username); err != nil {
+			l.send(client, &GameMessage{
+				Type: MsgError,
+				Data: err.Error(),
+			})
+			return
+		}
+
+		// The player might be the one the table is waiting for
+		l.broadcast()
+		if l.texas.HandNumber == 0 {
+			l.startGame()
+		} else {
+			l.scheduleNextHand()
+		}
+
+	case MsgTopUp:
+		l.topUp(client, gameMsg.Amount)
+
+	case MsgSeed:
+		if err := l.texas.SetSeed(client.user.Username, gameMsg.Data); err != nil {
+			l.send(client, &GameMessage{
+				Type: MsgError,
+				Data: err.Error(),
+			})
+			return
+		}
+
+		l.broadcast()
+
+	case MsgLeave:
+		log.Printf("[%s] Client %s is leaving the table", l.uuid[:10], client.user.Username)
+		if err := l.dismiss(client); err != nil {
+			log.Printf("[%s] Cannot remove %s from the table: %s", l.uuid[:10], client.user.Username, err)
+		}
+
 	default:
 		l.send(client, &GameMessage{
 			Type: MsgError,
@@ -86,52 +330,415 @@ func (l *lobby) send(client *Client, gameMsg *GameMessage) {
 	select {
 	case client.send <- *gameMsg:
 	default:
-		if err := l.disconnect(client); err != nil {
+		if err := l.removeClient(client); err != nil {
 			log.Printf("[%s] Cannot disconnect client: %s", l.uuid[:10], err)
 		}
 	}
 }
 
-// broadcast sends a state message to every client
+// broadcast sends a state message to every client and the spectators
 func (l *lobby) broadcast() {
 	for _, client := range l.clients {
-		sanitizedState := l.texas.SanitizeState(client.user.Username)
-		stateBytes, _ := json.Marshal(sanitizedState)
-		l.send(client, &GameMessage{
-			Type: MsgState,
-			Data: string(stateBytes),
-		})
+		l.sendState(client)
+	}
+
+	l.broadcastSpectators()
+}
+
+// sendState sends the game state as seen by the client.
+func (l *lobby) sendState(client *Client) {
+	sanitizedState := l.newTableState(l.texas.SanitizeState(client.user.Username))
+	stateBytes, _ := json.Marshal(sanitizedState)
+	l.send(client, &GameMessage{
+		Type: MsgState,
+		Data: string(stateBytes),
+	})
+}
+
+// scheduleNextHand deals the next hand after a delay if the current one is over.
+// A table waiting for the players to sit back in tries dealing again.
+func (l *lobby) scheduleNextHand() {
+	if !l.texas.IsHandOver() || l.texas.IsGameOver() || l.nextHandTimer != nil || l.handReported {
+		return
+	}
+
+	if !l.texas.IsWaiting() {
+		history := l.texas.History()
+		l.srv.recordHand(l.uuid, history)
+		l.countHand(history)
+	}
+
+	// The tournament director deals the next hand once the tables are balanced
+	if l.director != nil && !l.texas.IsWaiting() {
+		l.handReported = true
+		go l.director.handFinished(l)
+		return
+	}
+
+	l.dealNextHand()
+}
+
+// dealNextHand deals the next hand after a delay.
+func (l *lobby) dealNextHand() {
+	if l.nextHandTimer != nil {
+		return
+	}
+
+	log.Printf("[%s] Hand over, dealing the next one in %s", l.uuid[:10], nextHandDelay)
+	l.nextHandTimer = time.AfterFunc(nextHandDelay, l.nextHand)
+}
+
+// nextHand deals the next hand or ends the game if there are not enough players.
+func (l *lobby) nextHand() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.nextHandTimer = nil
+	if _, ok := l.srv.games.load(l.uuid); !ok {
+		// The game has already been deleted
+		return
+	}
+
+	l.rebuyBots()
+	l.settleLeaving()
+	if err := l.texas.NextHand(); err != nil {
+		log.Printf("[%s] Cannot deal the next hand: %s", l.uuid[:10], err)
+	}
+
+	l.updateTurnClock(true)
+
+	// Return the chips of the players who are still seated, a tournament pays the prizes
+	if l.texas.IsGameOver() {
+		l.payPrizes()
+		for _, client := range l.clients {
+			if player, ok := l.texas.FindPlayer(client.user.Username); ok {
+				l.settle(client.user, player)
+			}
+		}
+
+		for name, seat := range l.held {
+			if player, ok := l.texas.FindPlayer(name); ok {
+				l.settle(seat.user, player)
+			}
+
+			seat.timer.Stop()
+			delete(l.held, name)
+		}
+	}
+
+	l.broadcast()
+	if l.texas.ShouldBeDisbanded() || l.botsOnly() {
+		log.Printf("[%s] Game should be disbanded, deleting", l.uuid[:10])
+		l.shutdown()
 	}
 }
 
 // disconnect removes a client from the game.
 func (l *lobby) disconnect(c *Client) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.removeClient(c)
+}
+
+// removeClient removes a client from the lobby. A player seated in a running game
+// keeps their seat for the grace period, otherwise they leave the table.
+func (l *lobby) removeClient(c *Client) error {
+	if c.spectator {
+		if l.dropSpectator(c) {
+			l.broadcast()
+		}
+
+		return nil
+	}
+
+	if !l.dropClient(c) {
+		// The client has already been removed
+		return nil
+	}
+
+	player, seated := l.texas.FindPlayer(c.user.Username)
+	if seated && !player.Left && l.texas.HandNumber > 0 && !l.texas.IsGameOver() {
+		log.Printf("[%s] Client %s disconnected, holding their seat for %s", l.uuid[:10], c.user.Username, reconnectGrace)
+		seat := &heldSeat{user: c.user}
+		seat.timer = time.AfterFunc(reconnectGrace, func() {
+			l.releaseSeat(seat)
+		})
+		l.held[c.user.Username] = seat
+		l.broadcast()
+		return nil
+	}
+
+	return l.leaveTable(c.user)
+}
+
+// dropClient removes the client from the list of clients, it returns false if it wasn't there.
+func (l *lobby) dropClient(c *Client) bool {
 	for i, client := range l.clients {
 		if client == c {
 			l.clients = append(l.clients[:i], l.clients[i+1:]...)
-			break
+			return true
 		}
 	}
 
-	if err := l.texas.Disconnect(c.user.Username); err != nil {
+	return false
+}
+
+// releaseSeat makes the player who didn't reconnect in time leave the table.
+func (l *lobby) releaseSeat(seat *heldSeat) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.held[seat.user.Username] != seat {
+		// The player has reconnected in the meantime
+		return
+	}
+
+	delete(l.held, seat.user.Username)
+	if _, ok := l.srv.games.load(l.uuid); !ok {
+		// The game has already been deleted
+		return
+	}
+
+	log.Printf("[%s] Client %s didn't reconnect, releasing their seat", l.uuid[:10], seat.user.Username)
+	if err := l.leaveTable(seat.user); err != nil {
+		log.Printf("[%s] Cannot release the seat of %s: %s", l.uuid[:10], seat.user.Username, err)
+	}
+}
+
+// leaveTable folds the player's hand and returns the chips left in their stack to the wallet.
+func (l *lobby) leaveTable(user *models.User) error {
+	// The player loses their seat right away if the game hasn't started
+	player, seated := l.texas.FindPlayer(user.Username)
+	if err := l.texas.Disconnect(user.Username); err != nil {
 		if errors.Is(err, texas.OwnTurnDisconnectErr) {
-			log.Printf("[%s] Client %s disconnected during theier move, broadcasting", l.uuid[:10], c.user.Username)
-			l.broadcast()
+			log.Printf("[%s] Client %s left during their move, broadcasting", l.uuid[:10], user.Username)
+		} else if errors.Is(err, texas.PlayerNotInGameErr) {
+			// A client who never got a seat only has to be released
+			log.Printf("[%s] Client %s left without a seat", l.uuid[:10], user.Username)
 		} else {
-			log.Printf("[%s] Cannot disconnect client %s: %s", l.uuid[:10], c.user.Username, err)
+			log.Printf("[%s] Cannot disconnect client %s: %s", l.uuid[:10], user.Username, err)
 			return err
 		}
 	}
 
-	if l.texas.ShouldBeDisbanded() {
+	// A folded hand can't win anything, so the stack is final. An all-in hand is
+	// still played out, the player gets their chips once the hand is over
+	if seated && player.AllIn && !l.texas.IsHandOver() {
+		l.leaving[user.Username] = user
+	} else if seated {
+		l.settle(user, player)
+	}
+
+	l.srv.releaseUser(user)
+
+	l.updateTurnClock(false)
+	l.broadcast()
+	l.scheduleNextHand()
+
+	if l.texas.ShouldBeDisbanded() || l.botsOnly() {
 		log.Printf("[%s] Game should be disbanded, deleting", l.uuid[:10])
-		l.srv.deleteGame(l.uuid)
+		l.shutdown()
 	}
 
 	return nil
 }
 
-// isEmpty returns true if the lobby has no clients.
+// topUp adds the chips from the user's wallet to their stack, zero tops up to the maximum buy-in.
+func (l *lobby) topUp(client *Client, amount int) {
+	player, ok := l.texas.FindPlayer(client.user.Username)
+	if !ok {
+		l.send(client, &GameMessage{
+			Type: MsgError,
+			Data: texas.PlayerNotInGameErr.Error(),
+		})
+		return
+	}
+
+	if l.texas.Config.IsTournament() {
+		l.send(client, &GameMessage{
+			Type: MsgError,
+			Data: "There are no rebuys in a tournament",
+		})
+		return
+	}
+
+	if amount == 0 {
+		amount = l.texas.Config.MaxBuyIn - player.Assets
+	}
+
+	// The wallet is only charged for a positive amount
+	if amount <= 0 {
+		l.send(client, &GameMessage{
+			Type: MsgError,
+			Data: texas.InvalidAssetErr.Error(),
+		})
+		return
+	}
+
+	if err := l.srv.topUp(client.user, l.uuid, amount); err != nil {
+		log.Printf("[%s] Cannot top up for %s: %s", l.uuid[:10], client.user.Username, err)
+		l.send(client, &GameMessage{
+			Type: MsgError,
+			Data: "Cannot top up: " + err.Error(),
+		})
+		return
+	}
+
+	if err := l.texas.TopUp(client.user.Username, amount); err != nil {
+		l.srv.cashOut(client.user, l.uuid, amount)
+		l.send(client, &GameMessage{
+			Type: MsgError,
+			Data: err.Error(),
+		})
+		return
+	}
+
+	log.Printf("[%s] %s topped up %d chips", l.uuid[:10], client.user.Username, amount)
+	l.broadcast()
+}
+
+// kick removes the player from the lobby before the first hand, the buy-in is returned.
+func (l *lobby) kick(username string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.texas.HandNumber > 0 {
+		return texas.GameStillInProgressErr
+	}
+
+	for _, client := range l.clients {
+		if client.user.Username != username {
+			continue
+		}
+
+		log.Printf("[%s] Kicking %s from the table", l.uuid[:10], username)
+		l.send(client, &GameMessage{
+			Type: MsgError,
+			Data: "You have been removed from the table",
+		})
+
+		return l.dismiss(client)
+	}
+
+	return nil
+}
+
+// dismiss makes the client leave the table and closes their connection, the user can join another game.
+func (l *lobby) dismiss(client *Client) error {
+	l.dropClient(client)
+	if err := l.leaveTable(client.user); err != nil {
+		return err
+	}
+
+	// Give the client a moment to receive the queued messages before closing the connection
+	time.AfterFunc(writeWait, func() {
+		client.conn.Close()
+	})
+	return nil
+}
+
+// moveClient tells the player moved to another table of the tournament to
+// connect there and closes their connection, a held seat is released.
+func (l *lobby) moveClient(username string, table string) {
+	if seat, ok := l.held[username]; ok {
+		seat.timer.Stop()
+		delete(l.held, username)
+	}
+
+	for _, client := range l.clients {
+		if client.user.Username != username {
+			continue
+		}
+
+		l.send(client, &GameMessage{
+			Type: MsgMove,
+			Data: table,
+		})
+
+		l.dropClient(client)
+		time.AfterFunc(writeWait, func() {
+			client.conn.Close()
+		})
+		return
+	}
+}
+
+// settleLeaving returns the chips of the players who left while all-in.
+func (l *lobby) settleLeaving() {
+	for name, user := range l.leaving {
+		if player, ok := l.texas.FindPlayer(name); ok {
+			l.settle(user, player)
+		}
+
+		delete(l.leaving, name)
+	}
+}
+
+// unregister removes the client from the lobby and deletes the game once nobody is left.
+func (l *lobby) unregister(c *Client) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if _, ok := l.srv.games.load(l.uuid); !ok {
+		// The game has already been deleted, only the client has to be forgotten
+		l.dropClient(c)
+		l.dropSpectator(c)
+		return
+	}
+
+	if err := l.removeClient(c); err != nil {
+		log.Printf("[%s] Error disconnecting client: %s", l.uuid[:10], err)
+	}
+
+	if l.isEmpty() {
+		log.Printf("[%s] Deleting game", l.uuid[:10])
+		l.shutdown()
+	}
+}
+
+// isEmpty returns true if the lobby has no clients and no seats waiting for a reconnect,
+// the tables of a multi-table tournament are closed by the director.
 func (l *lobby) isEmpty() bool {
-	return len(l.clients) == 0
+	return l.director == nil && len(l.clients) == 0 && len(l.held) == 0
+}
+
+// shutdown deletes the game, drops the clients and stops the timers of the table.
+// It must be called with the lobby locked, a deleted game is left alone.
+func (l *lobby) shutdown() {
+	if _, ok := l.srv.games.load(l.uuid); !ok {
+		return
+	}
+
+	if l.nextHandTimer != nil {
+		l.nextHandTimer.Stop()
+		l.nextHandTimer = nil
+	}
+
+	for name, seat := range l.held {
+		seat.timer.Stop()
+		delete(l.held, name)
+	}
+
+	l.settleLeaving()
+	l.stopTurnClock()
+	l.stopBot()
+	if l.backfillTimer != nil {
+		l.backfillTimer.Stop()
+		l.backfillTimer = nil
+	}
+
+	if l.levelTimer != nil {
+		l.levelTimer.Stop()
+		l.levelTimer = nil
+	}
+
+	for _, timer := range l.spectatorTimers {
+		timer.Stop()
+	}
+
+	l.spectatorTimers = nil
+	l.spectators = nil
+	l.clients = nil
+	l.srv.deleteGame(l.uuid)
 }
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+)
+
+// addSpectator adds a spectator to the game, they get the last state the spectators have seen.
+func (l *lobby) addSpectator(c *Client) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	log.Printf("[%s] Adding spectator %s to the game", l.uuid[:10], c.user.Username)
+	l.spectators = append(l.spectators, c)
+	l.replayChat(c)
+	if l.spectatorState != "" {
+		l.send(c, &GameMessage{
+			Type: MsgState,
+			Data: l.spectatorState,
+		})
+	}
+
+	l.broadcast()
+}
+
+// broadcastSpectators sends the state with every hole card hidden to the spectators,
+// it is delayed so the spectators cannot tell the players what is going on.
+func (l *lobby) broadcastSpectators() {
+	stateBytes, _ := json.Marshal(l.newTableState(l.texas.SanitizeState("")))
+	if l.srv.spectatorDelay == 0 {
+		l.deliverSpectatorState(string(stateBytes))
+		return
+	}
+
+	// The timer is saved before the callback can take the lock
+	var timer *time.Timer
+	timer = time.AfterFunc(l.srv.spectatorDelay, func() {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+
+		l.dropSpectatorTimer(timer)
+		if _, ok := l.srv.games.load(l.uuid); !ok {
+			// The game has already been deleted
+			return
+		}
+
+		l.deliverSpectatorState(string(stateBytes))
+	})
+	l.spectatorTimers = append(l.spectatorTimers, timer)
+}
+
+// dropSpectatorTimer forgets the timer of a delivered spectator state.
+func (l *lobby) dropSpectatorTimer(timer *time.Timer) {
+	for i, other := range l.spectatorTimers {
+		if other == timer {
+			l.spectatorTimers = append(l.spectatorTimers[:i], l.spectatorTimers[i+1:]...)
+			return
+		}
+	}
+}
+
+// deliverSpectatorState sends the state to every spectator.
+func (l *lobby) deliverSpectatorState(state string) {
+	l.spectatorState = state
+	for _, spectator := range l.spectators {
+		l.send(spectator, &GameMessage{
+			Type: MsgState,
+			Data: state,
+		})
+	}
+}
+
+// dropSpectator removes the spectator from the lobby, it returns false if they weren't there.
+func (l *lobby) dropSpectator(c *Client) bool {
+	for i, spectator := range l.spectators {
+		if spectator == c {
+			l.spectators = append(l.spectators[:i], l.spectators[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
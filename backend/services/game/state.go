@@ -0,0 +1,61 @@
+package game
+
+import (
+	"sort"
+	"time"
+
+	"github.com/TypicalAM/gopoker/texas"
+)
+
+// tableState is the game state sent to a client along with the lobby information.
+type tableState struct {
+	*texas.TexasHoldEm
+
+	// The milliseconds the player to act has left and the milliseconds left in their time bank
+	TimeLeft      int64
+	TimeBank      int64
+	UsingTimeBank bool
+
+	// The players whose seats are held until they reconnect
+	Disconnected []string
+
+	// The number of people watching the game
+	Spectators int
+
+	// The milliseconds until the tournament blinds go up and the prizes once it is over
+	NextLevel int64
+	Payouts   []texas.Payout
+}
+
+// newTableState creates the state sent to a client from the sanitized game state.
+func (l *lobby) newTableState(state *texas.TexasHoldEm) tableState {
+	tableState := tableState{
+		TexasHoldEm:  state,
+		Disconnected: []string{},
+		Spectators:   len(l.spectators),
+		Payouts:      l.texas.Payouts(),
+	}
+
+	if l.levelTimer != nil {
+		tableState.NextLevel = time.Until(l.levelDeadline).Milliseconds()
+	}
+
+	for name := range l.held {
+		tableState.Disconnected = append(tableState.Disconnected, name)
+	}
+
+	sort.Strings(tableState.Disconnected)
+	if l.turnTimer == nil {
+		return tableState
+	}
+
+	tableState.TimeLeft = time.Until(l.turnDeadline).Milliseconds()
+	tableState.UsingTimeBank = !l.bankStarted.IsZero()
+	if !tableState.UsingTimeBank {
+		tableState.TimeBank = l.timeBank(l.turnPlayer).Milliseconds()
+	} else {
+		tableState.TimeBank = tableState.TimeLeft
+	}
+
+	return tableState
+}
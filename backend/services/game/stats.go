@@ -0,0 +1,58 @@
+package game
+
+import (
+	"time"
+
+	"github.com/TypicalAM/gopoker/texas"
+)
+
+// TableStats are the live statistics of a table running on the server.
+type TableStats struct {
+	Seated       int
+	Hands        int
+	AveragePot   int
+	HandsPerHour float64
+}
+
+// Stats returns the statistics of a running table, false is returned if the table isn't running.
+func (srv *Server) Stats(uuid string) (TableStats, bool) {
+	lobby, ok := srv.games.load(uuid)
+	if !ok {
+		return TableStats{}, false
+	}
+
+	return lobby.stats(), true
+}
+
+// stats calculates the statistics of the table.
+func (l *lobby) stats() TableStats {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	stats := TableStats{Hands: l.handsPlayed}
+	for _, player := range l.texas.Players {
+		if !player.Left {
+			stats.Seated++
+		}
+	}
+
+	if l.handsPlayed > 0 {
+		stats.AveragePot = l.potTotal / l.handsPlayed
+	}
+
+	if !l.startedAt.IsZero() {
+		if elapsed := time.Since(l.startedAt).Hours(); elapsed > 0 {
+			stats.HandsPerHour = float64(l.handsPlayed) / elapsed
+		}
+	}
+
+	return stats
+}
+
+// countHand adds a finished hand to the statistics of the table.
+func (l *lobby) countHand(history texas.HandHistory) {
+	l.handsPlayed++
+	for _, pot := range history.Pots {
+		l.potTotal += pot.Amount
+	}
+}
@@ -0,0 +1,63 @@
+package game
+
+import (
+	"log"
+	"time"
+
+	"github.com/TypicalAM/gopoker/models"
+	"github.com/TypicalAM/gopoker/texas"
+)
+
+// settle returns the chips of a player leaving the table to their wallet,
+// the tournament buy-in is only returned before the tournament starts.
+func (l *lobby) settle(user *models.User, player texas.Player) {
+	if !l.texas.Config.IsTournament() {
+		l.srv.cashOut(user, l.uuid, player.Assets)
+		return
+	}
+
+	if l.texas.HandNumber == 0 {
+		l.srv.refundEntry(user, l.uuid, l.texas.Config.BuyIn)
+	}
+}
+
+// scheduleLevel raises the tournament blinds once the level is over.
+func (l *lobby) scheduleLevel() {
+	duration := time.Duration(l.texas.Config.LevelMinutes) * time.Minute
+	l.levelDeadline = time.Now().Add(duration)
+	l.levelTimer = time.AfterFunc(duration, l.raiseBlinds)
+}
+
+// raiseBlinds moves the tournament to the next blind level.
+func (l *lobby) raiseBlinds() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.levelTimer = nil
+	if _, ok := l.srv.games.load(l.uuid); !ok {
+		// The game has already been deleted
+		return
+	}
+
+	if l.texas.IsGameOver() {
+		return
+	}
+
+	if err := l.texas.RaiseBlinds(); err != nil {
+		log.Printf("[%s] Cannot raise the blinds: %s", l.uuid[:10], err)
+		return
+	}
+
+	level := texas.BlindSchedule[l.texas.Level]
+	log.Printf("[%s] The blinds go up to %d/%d with the next hand", l.uuid[:10], level.SmallBlind, level.BigBlind)
+	l.scheduleLevel()
+	l.broadcast()
+}
+
+// payPrizes pays the tournament prizes to the players in the paid places.
+func (l *lobby) payPrizes() {
+	for _, payout := range l.texas.Payouts() {
+		log.Printf("[%s] %s finished in place %d and wins %d", l.uuid[:10], payout.Name, payout.Place, payout.Amount)
+		l.srv.payPrize(payout.Name, l.uuid, payout.Amount)
+	}
+}
@@ -0,0 +1,179 @@
+package texas
+
+import "fmt"
+
+// BettingStructure decides how much a player is allowed to raise.
+type BettingStructure string
+
+const (
+	NoLimit    BettingStructure = "no-limit"
+	PotLimit   BettingStructure = "pot-limit"
+	FixedLimit BettingStructure = "fixed-limit"
+)
+
+var structureMap = map[string]BettingStructure{
+	"no-limit":    NoLimit,
+	"pot-limit":   PotLimit,
+	"fixed-limit": FixedLimit,
+}
+
+// fixedLimitCap is the maximum number of bets and raises on a fixed-limit street.
+const fixedLimitCap = 4
+
+// RaiseBounds returns the smallest and the largest amount the player can bet or raise to.
+// A player who cannot cover the minimum raise may still raise all-in.
+func (t *TexasHoldEm) RaiseBounds(index int) (int, int) {
+	player := t.Players[index]
+	stack := player.Bet + player.Assets
+
+	minRaise := t.ActiveBet + t.LastRaise
+	maxRaise := stack
+	switch t.Config.Structure {
+	case PotLimit:
+		maxRaise = t.ActiveBet + t.PotTotal() + t.ActiveBet - player.Bet
+
+	case FixedLimit:
+		minRaise = t.ActiveBet + t.limitSize()
+		maxRaise = minRaise
+	}
+
+	if maxRaise > stack {
+		maxRaise = stack
+	}
+
+	if minRaise > stack {
+		minRaise = stack
+	}
+
+	return minRaise, maxRaise
+}
+
+// validateRaise checks if the player can raise their bet to the amount.
+func (t *TexasHoldEm) validateRaise(index int, amount int) error {
+	player := t.Players[index]
+	if player.Bet+player.Assets <= t.ActiveBet {
+		return NotEnoughMoneyErr
+	}
+
+	if t.raiseCapped() {
+		return fmt.Errorf("%w: the betting is capped at %d raises", InvalidBetSizeErr, fixedLimitCap)
+	}
+
+	minRaise, maxRaise := t.RaiseBounds(index)
+	if amount < minRaise || amount > maxRaise {
+		if minRaise == maxRaise {
+			return fmt.Errorf("%w: you can only raise to %d", InvalidBetSizeErr, minRaise)
+		}
+
+		return fmt.Errorf("%w: raise must be between %d and %d", InvalidBetSizeErr, minRaise, maxRaise)
+	}
+
+	return nil
+}
+
+// raiseCapped returns true if the fixed-limit street has had all the raises it allows.
+func (t *TexasHoldEm) raiseCapped() bool {
+	return t.Config.Structure == FixedLimit && t.raiseCount >= fixedLimitCap
+}
+
+// raiseTo raises the player's bet to the amount and updates the minimum raise.
+func (t *TexasHoldEm) raiseTo(index int, amount int) {
+	t.commit(index, amount-t.Players[index].Bet)
+	if t.Players[index].Bet <= t.ActiveBet {
+		return
+	}
+
+	if size := t.Players[index].Bet - t.ActiveBet; size > t.LastRaise {
+		t.LastRaise = size
+	}
+
+	t.ActiveBet = t.Players[index].Bet
+	t.raiseCount++
+}
+
+// limitSize is the bet size on the current street of a fixed-limit game.
+func (t *TexasHoldEm) limitSize() int {
+	if t.Round == Turn || t.Round == River {
+		return 2 * t.Config.BigBlind
+	}
+
+	return t.Config.BigBlind
+}
+
+// DecodeStructure decodes a betting structure from its name.
+func DecodeStructure(text string) (BettingStructure, bool) {
+	val, ok := structureMap[text]
+	return val, ok
+}
+
+// Legal holds the moves the player to act is allowed to make, the amount
+// they have to put in to call and the totals they can bet or raise to.
+type Legal struct {
+	Actions  []PokerAction `json:"actions"`
+	Call     int           `json:"call"`
+	MinRaise int           `json:"min_raise,omitempty"`
+	MaxRaise int           `json:"max_raise,omitempty"`
+}
+
+// Allows returns true if the action is legal.
+func (l Legal) Allows(action PokerAction) bool {
+	for _, legal := range l.Actions {
+		if legal == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LegalActions returns the moves the player can make, it is empty if it isn't their turn.
+func (t *TexasHoldEm) LegalActions(username string) Legal {
+	legal := Legal{Actions: []PokerAction{}}
+	if t.HandNumber == 0 || t.HandOver || t.CurrentPlayer < 0 || t.CurrentPlayer >= len(t.Players) {
+		return legal
+	}
+
+	index := t.CurrentPlayer
+	player := t.Players[index]
+	if player.Name != username || !player.Active || player.AllIn {
+		return legal
+	}
+
+	owed := t.ActiveBet - player.Bet
+	legal.Call = owed
+	if legal.Call > player.Assets {
+		legal.Call = player.Assets
+	}
+
+	legal.Actions = append(legal.Actions, Fold)
+	if t.canCheck(index) {
+		legal.Actions = append(legal.Actions, Check)
+	}
+
+	if owed > 0 {
+		legal.Actions = append(legal.Actions, Call)
+	}
+
+	if player.Assets > owed && !t.raiseCapped() {
+		if t.ActiveBet == 0 {
+			legal.Actions = append(legal.Actions, Bet)
+		} else {
+			legal.Actions = append(legal.Actions, Raise)
+		}
+
+		legal.MinRaise, legal.MaxRaise = t.RaiseBounds(index)
+	}
+
+	// An all-in raise is held to the same limits as any other raise
+	allIn := player.Bet + player.Assets
+	if player.Assets > 0 && (t.Config.Structure == NoLimit || allIn <= t.ActiveBet || allIn <= legal.MaxRaise) {
+		legal.Actions = append(legal.Actions, AllIn)
+	}
+
+	return legal
+}
+
+// canCheck returns true if the player is allowed to check, they can if nothing is owed.
+func (t *TexasHoldEm) canCheck(index int) bool {
+	return t.Players[index].Bet >= t.ActiveBet
+}
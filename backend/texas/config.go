@@ -0,0 +1,87 @@
+package texas
+
+import "errors"
+
+var InvalidConfigErr = errors.New("Invalid table config")
+var TableFullErr = errors.New("Table is full")
+
+// MinimumPlayers is the fewest players a hand can be dealt to, a heads-up match.
+const MinimumPlayers = 2
+
+// TableConfig holds the stakes and the limits of a table.
+type TableConfig struct {
+	SmallBlind    int
+	BigBlind      int
+	Ante          int
+	StartingStack int
+	MinBuyIn      int
+	MaxBuyIn      int
+	MinPlayers    int
+	MaxPlayers    int
+	Structure     BettingStructure
+
+	// The seconds a player has to act and the extra seconds they can spend
+	// over the whole game, a zero action time means the table isn't timed
+	ActionTime int
+	TimeBank   int
+
+	// A sit-and-go charges the buy-in for the starting stack, the blinds go up
+	// every level. A zero buy-in means a cash game
+	BuyIn        int
+	LevelMinutes int
+}
+
+// DefaultConfig returns the config of a 1/2 no-limit table.
+func DefaultConfig() TableConfig {
+	return TableConfig{
+		SmallBlind:    1,
+		BigBlind:      2,
+		Ante:          0,
+		StartingStack: 100,
+		MinBuyIn:      40,
+		MaxBuyIn:      200,
+		MinPlayers:    3,
+		MaxPlayers:    3,
+		Structure:     NoLimit,
+		ActionTime:    30,
+		TimeBank:      60,
+	}
+}
+
+// IsTournament returns true if the table is a sit-and-go.
+func (c TableConfig) IsTournament() bool {
+	return c.BuyIn > 0
+}
+
+// Validate checks if the stakes and the limits make sense.
+func (c TableConfig) Validate() error {
+	if c.SmallBlind <= 0 || c.BigBlind < c.SmallBlind || c.Ante < 0 {
+		return InvalidConfigErr
+	}
+
+	if c.MinBuyIn <= 0 || c.MaxBuyIn < c.MinBuyIn {
+		return InvalidConfigErr
+	}
+
+	if c.StartingStack < c.MinBuyIn || c.StartingStack > c.MaxBuyIn {
+		return InvalidConfigErr
+	}
+
+	if c.MinPlayers < MinimumPlayers || c.MaxPlayers < c.MinPlayers {
+		return InvalidConfigErr
+	}
+
+	if c.ActionTime < 0 || c.TimeBank < 0 {
+		return InvalidConfigErr
+	}
+
+	if c.BuyIn < 0 || c.LevelMinutes < 0 || (c.IsTournament() && c.LevelMinutes == 0) {
+		return InvalidConfigErr
+	}
+
+	if _, ok := structureMap[string(c.Structure)]; !ok {
+		return InvalidConfigErr
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package texas
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/chehsunliu/poker"
+)
+
+var InvalidCardErr = errors.New("Invalid card")
+
+// DeckSource gives the deck every hand is dealt from. The hole cards are dealt
+// two to every player in seat order from the top of the deck, then come the
+// flop, the turn and the river. Only the fair shuffle matches the commitment
+// published to the players, the other sources are meant for tests and replays.
+type DeckSource interface {
+	Deck(seeds ShuffleSeeds) ([]poker.Card, error)
+}
+
+// FairShuffle shuffles the deck with the committed server seed and the player seeds.
+type FairShuffle struct{}
+
+// Deck shuffles the deck with the seeds of the hand.
+func (FairShuffle) Deck(seeds ShuffleSeeds) ([]poker.Card, error) {
+	return ShuffleDeck(seeds.ServerSeed, seeds.PlayerSeeds)
+}
+
+// SeededDeck shuffles the decks with a seeded random number generator, the
+// same seed deals the same hands.
+type SeededDeck struct {
+	rand *rand.Rand
+}
+
+// NewSeededDeck creates the deck source with the seed.
+func NewSeededDeck(seed int64) *SeededDeck {
+	return &SeededDeck{rand: rand.New(rand.NewSource(seed))}
+}
+
+// Deck shuffles the next deck, the seeds of the hand are ignored.
+func (s *SeededDeck) Deck(ShuffleSeeds) ([]poker.Card, error) {
+	deck := orderedDeck()
+	s.rand.Shuffle(len(deck), func(i, j int) {
+		deck[i], deck[j] = deck[j], deck[i]
+	})
+
+	return deck, nil
+}
+
+// StackedDeck deals the cards in the given order, one sequence for every
+// hand. The cards missing from a sequence follow in the order of a new deck.
+type StackedDeck struct {
+	hands [][]poker.Card
+	next  int
+}
+
+// NewStackedDeck creates the deck source from the card sequences of the hands,
+// the cards are separated by spaces, for example "As Kd 7c 7h Qs Qd".
+func NewStackedDeck(hands ...string) (*StackedDeck, error) {
+	stacked := &StackedDeck{}
+	for _, hand := range hands {
+		cards, err := ParseCards(hand)
+		if err != nil {
+			return nil, err
+		}
+
+		stacked.hands = append(stacked.hands, cards)
+	}
+
+	return stacked, nil
+}
+
+// ReplayDeck creates the deck source which deals the recorded hands again,
+// the players have to be seated in the same order.
+func ReplayDeck(histories ...HandHistory) *StackedDeck {
+	stacked := &StackedDeck{}
+	for _, history := range histories {
+		cards := []poker.Card{}
+		for _, seat := range history.Seats {
+			cards = append(cards, seat.HoleCards...)
+		}
+
+		stacked.hands = append(stacked.hands, append(cards, history.Board...))
+	}
+
+	return stacked
+}
+
+// Deck returns the stacked deck of the next hand.
+func (s *StackedDeck) Deck(ShuffleSeeds) ([]poker.Card, error) {
+	if s.next >= len(s.hands) {
+		return nil, fmt.Errorf("%w: the stacked deck has only %d hands", DrawErr, len(s.hands))
+	}
+
+	stacked := s.hands[s.next]
+	s.next++
+	deck := append([]poker.Card{}, stacked...)
+	for _, card := range orderedDeck() {
+		if !containsCard(stacked, card) {
+			deck = append(deck, card)
+		}
+	}
+
+	return deck, nil
+}
+
+// SetDeckSource replaces the fair shuffle with another deck source, the deck is used from the next hand on.
+func (t *TexasHoldEm) SetDeckSource(source DeckSource) {
+	t.source = source
+}
+
+// deckSource returns the source of the decks, the fair shuffle is the default.
+func (t *TexasHoldEm) deckSource() DeckSource {
+	if t.source == nil {
+		return FairShuffle{}
+	}
+
+	return t.source
+}
+
+// ParseCards parses the cards separated by spaces, a card can't repeat.
+func ParseCards(text string) ([]poker.Card, error) {
+	cards := []poker.Card{}
+	for _, name := range strings.Fields(text) {
+		if len(name) != 2 || !strings.ContainsRune("23456789TJQKA", rune(name[0])) || !strings.ContainsRune("shdc", rune(name[1])) {
+			return nil, fmt.Errorf("%w: %s", InvalidCardErr, name)
+		}
+
+		card := poker.NewCard(name)
+		if containsCard(cards, card) {
+			return nil, fmt.Errorf("%w: %s is there twice", InvalidCardErr, name)
+		}
+
+		cards = append(cards, card)
+	}
+
+	return cards, nil
+}
+
+// containsCard returns true if the card is in the list.
+func containsCard(cards []poker.Card, card poker.Card) bool {
+	for _, c := range cards {
+		if c == card {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,213 @@
+package texas
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/chehsunliu/poker"
+)
+
+var CommitmentMismatchErr = errors.New("The server seed doesn't match the commitment")
+var DealMismatchErr = errors.New("The cards don't match the deck")
+var InvalidSeedErr = errors.New("Invalid seed")
+
+// maxSeedLength is the longest seed a player can contribute.
+const maxSeedLength = 64
+
+// serverSeedBytes is the size of the secret server seed.
+const serverSeedBytes = 32
+
+// PlayerSeed is the entropy a player contributed to the shuffle of a hand.
+type PlayerSeed struct {
+	Name string `json:"name"`
+	Seed string `json:"seed"`
+}
+
+// ShuffleSeeds are the seeds the deck of a hand is shuffled with. The commitment
+// is published before the hand is dealt, the server seed stays on the server and
+// the hands are verified against it there. The player seeds are in the order the hole cards are dealt.
+type ShuffleSeeds struct {
+	Commitment  string
+	ServerSeed  string
+	PlayerSeeds []PlayerSeed
+}
+
+// SetSeed sets the entropy the player contributes to the shuffles of the next hands.
+func (t *TexasHoldEm) SetSeed(username string, seed string) error {
+	index := t.playerIndex(username)
+	if index == -1 {
+		return PlayerNotInGameErr
+	}
+
+	if len(seed) > maxSeedLength {
+		return fmt.Errorf("%w: the seed can be at most %d characters long", InvalidSeedErr, maxSeedLength)
+	}
+
+	t.Players[index].Seed = seed
+	return nil
+}
+
+// useSeeds takes the committed server seed for the hand about to be dealt and
+// commits to the seed of the next one.
+func (t *TexasHoldEm) useSeeds() error {
+	if t.nextSeed == "" {
+		if err := t.commitNextSeed(); err != nil {
+			return err
+		}
+	}
+
+	t.Seeds = ShuffleSeeds{
+		Commitment:  t.NextCommitment,
+		ServerSeed:  t.nextSeed,
+		PlayerSeeds: []PlayerSeed{},
+	}
+
+	for _, player := range t.Players {
+		if player.dealtIn() {
+			t.Seeds.PlayerSeeds = append(t.Seeds.PlayerSeeds, PlayerSeed{Name: player.Name, Seed: player.Seed})
+		}
+	}
+
+	return t.commitNextSeed()
+}
+
+// commitNextSeed generates the secret seed of the next hand and publishes its commitment.
+func (t *TexasHoldEm) commitNextSeed() error {
+	seed := make([]byte, serverSeedBytes)
+	if _, err := rand.Read(seed); err != nil {
+		return fmt.Errorf("%w: %s", DrawErr, err)
+	}
+
+	t.nextSeed = hex.EncodeToString(seed)
+	t.NextCommitment = Commit(t.nextSeed)
+	return nil
+}
+
+// Commit returns the commitment to the hex encoded server seed, the hex encoded SHA-256 hash of its bytes.
+func Commit(serverSeed string) string {
+	seed, err := hex.DecodeString(serverSeed)
+	if err != nil {
+		return ""
+	}
+
+	hash := sha256.Sum256(seed)
+	return hex.EncodeToString(hash[:])
+}
+
+// ShuffleDeck returns the deck of a hand shuffled with the seeds. The deck
+// starts ordered by rank from the deuce and by suit (spades, hearts, diamonds,
+// clubs) within the rank. The player seeds make the message: the length of the
+// name as a big-endian uint32, the name, the length of the seed and the seed for
+// every player. The n-th block of the random stream is the HMAC-SHA256 of the
+// message followed by n as a big-endian uint64, keyed with the server seed bytes.
+// The stream is read as big-endian uint32 numbers and the deck is shuffled from
+// the last card down, card i is swapped with card x mod (i+1) where x is the
+// first number lower than the largest multiple of i+1 that fits in a uint32.
+func ShuffleDeck(serverSeed string, playerSeeds []PlayerSeed) ([]poker.Card, error) {
+	key, err := hex.DecodeString(serverSeed)
+	if err != nil || len(key) == 0 {
+		return nil, InvalidSeedErr
+	}
+
+	message := []byte{}
+	for _, seed := range playerSeeds {
+		message = binary.BigEndian.AppendUint32(message, uint32(len(seed.Name)))
+		message = append(message, seed.Name...)
+		message = binary.BigEndian.AppendUint32(message, uint32(len(seed.Seed)))
+		message = append(message, seed.Seed...)
+	}
+
+	stream := &seedStream{key: key, message: message}
+	deck := orderedDeck()
+	for i := len(deck) - 1; i > 0; i-- {
+		j := stream.uniform(uint32(i + 1))
+		deck[i], deck[j] = deck[j], deck[i]
+	}
+
+	return deck, nil
+}
+
+// VerifyDeal checks the server seed against the commitment and recomputes the
+// deck, the known hole cards and the board have to be dealt from it. The hole
+// cards are dealt two to every player in the order of the player seeds, then
+// come the flop, the turn and the river.
+func VerifyDeal(seeds ShuffleSeeds, holeCards map[string][]poker.Card, board []poker.Card) ([]poker.Card, error) {
+	if Commit(seeds.ServerSeed) != seeds.Commitment {
+		return nil, CommitmentMismatchErr
+	}
+
+	deck, err := ShuffleDeck(seeds.ServerSeed, seeds.PlayerSeeds)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, seed := range seeds.PlayerSeeds {
+		cards := holeCards[seed.Name]
+		if len(cards) == 0 {
+			continue
+		}
+
+		if len(cards) != 2 || cards[0] != deck[2*i] || cards[1] != deck[2*i+1] {
+			return deck, fmt.Errorf("%w: the hole cards of %s", DealMismatchErr, seed.Name)
+		}
+	}
+
+	dealt := 2 * len(seeds.PlayerSeeds)
+	for i, card := range board {
+		if dealt+i >= len(deck) || card != deck[dealt+i] {
+			return deck, fmt.Errorf("%w: the board", DealMismatchErr)
+		}
+	}
+
+	return deck, nil
+}
+
+// orderedDeck returns the deck before the shuffle.
+func orderedDeck() []poker.Card {
+	deck := make([]poker.Card, 0, 52)
+	for _, rank := range "23456789TJQKA" {
+		for _, suit := range "shdc" {
+			deck = append(deck, poker.NewCard(string(rank)+string(suit)))
+		}
+	}
+
+	return deck
+}
+
+// seedStream is the random stream the deck is shuffled with.
+type seedStream struct {
+	key     []byte
+	message []byte
+	block   uint64
+	buffer  []byte
+}
+
+// next returns the next number of the stream.
+func (s *seedStream) next() uint32 {
+	if len(s.buffer) < 4 {
+		mac := hmac.New(sha256.New, s.key)
+		mac.Write(s.message)
+		mac.Write(binary.BigEndian.AppendUint64(nil, s.block))
+		s.buffer = mac.Sum(nil)
+		s.block++
+	}
+
+	number := binary.BigEndian.Uint32(s.buffer)
+	s.buffer = s.buffer[4:]
+	return number
+}
+
+// uniform returns a number lower than n without a bias towards the low numbers.
+func (s *seedStream) uniform(n uint32) uint32 {
+	limit := (1 << 32) / uint64(n) * uint64(n)
+	for {
+		if number := s.next(); uint64(number) < limit {
+			return number % n
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package texas
+
+import "github.com/chehsunliu/poker"
+
+const (
+	PostAnte       PokerAction = "ante"
+	PostSmallBlind PokerAction = "small blind"
+	PostBigBlind   PokerAction = "big blind"
+)
+
+// HandHistory is the record of a single hand.
+type HandHistory struct {
+	Number     int
+	Structure  BettingStructure
+	MaxPlayers int
+	SmallBlind int
+	BigBlind   int
+	Ante       int
+	Dealer     int
+	Seats      []SeatHistory
+	Actions    []ActionHistory
+	Board      []poker.Card
+	Pots       []Pot
+	Winners    []Winner
+	Seeds      ShuffleSeeds
+}
+
+// SeatHistory is a player dealt into the hand, the stack is counted before the antes and the blinds.
+type SeatHistory struct {
+	Seat      int
+	Name      string
+	Stack     int
+	HoleCards []poker.Card
+	Shown     bool
+}
+
+// ActionHistory is a single action in the hand, the amount is the number of
+// chips put into the pot and the bet is the player's bet on the street after the action.
+type ActionHistory struct {
+	Round  pokerRound
+	Name   string
+	Action PokerAction
+	Amount int
+	Bet    int
+}
+
+// History returns the record of the current hand.
+func (t *TexasHoldEm) History() HandHistory {
+	history := t.history
+	history.Seats = make([]SeatHistory, len(t.history.Seats))
+	copy(history.Seats, t.history.Seats)
+	history.Actions = make([]ActionHistory, len(t.history.Actions))
+	copy(history.Actions, t.history.Actions)
+	history.Board = make([]poker.Card, len(t.CommunityCards))
+	copy(history.Board, t.CommunityCards)
+	history.Pots = t.Pots
+	history.Winners = t.Winners
+	return history
+}
+
+// startHistory starts recording a freshly dealt hand.
+func (t *TexasHoldEm) startHistory() {
+	t.history = HandHistory{
+		Number:     t.HandNumber,
+		Structure:  t.Config.Structure,
+		MaxPlayers: t.Config.MaxPlayers,
+		SmallBlind: t.Config.SmallBlind,
+		BigBlind:   t.Config.BigBlind,
+		Ante:       t.Config.Ante,
+		Dealer:     t.Players[t.Dealer].Seat,
+		Seats:      []SeatHistory{},
+		Actions:    []ActionHistory{},
+		Seeds:      t.Seeds,
+	}
+
+	for _, player := range t.Players {
+		if !player.Active {
+			continue
+		}
+
+		t.history.Seats = append(t.history.Seats, SeatHistory{
+			Seat:      player.Seat,
+			Name:      player.Name,
+			Stack:     player.Assets,
+			HoleCards: player.HoleCards,
+		})
+	}
+}
+
+// record adds the player's action to the history.
+func (t *TexasHoldEm) record(index int, action PokerAction, amount int) {
+	t.history.Actions = append(t.history.Actions, ActionHistory{
+		Round:  t.Round,
+		Name:   t.Players[index].Name,
+		Action: action,
+		Amount: amount,
+		Bet:    t.Players[index].Bet,
+	})
+}
+
+// isShown checks if the player showed their cards at the showdown.
+func (t *TexasHoldEm) isShown(username string) bool {
+	for _, seat := range t.history.Seats {
+		if seat.Name == username {
+			return seat.Shown
+		}
+	}
+
+	return false
+}
+
+// showCards marks the players who reached the showdown.
+func (t *TexasHoldEm) showCards() {
+	for i := range t.history.Seats {
+		index := t.playerIndex(t.history.Seats[i].Name)
+		if index != -1 && t.Players[index].Active {
+			t.history.Seats[i].Shown = true
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package texas
+
+import (
+	"math"
+	"sort"
+
+	"github.com/chehsunliu/poker"
+)
+
+// Pot is a main or side pot, only the eligible players can win it.
+type Pot struct {
+	Amount   int
+	Eligible []string
+	Winners  []string
+}
+
+// Winner is a player who won chips at the end of the hand.
+type Winner struct {
+	Name   string
+	Amount int
+	Rank   string
+	Hand   []poker.Card
+}
+
+// updatePots rebuilds the pots from the amount every player has committed
+// in the current hand. A new side pot is opened at every all-in level, the
+// chips of a folded player above every live bet are dead money in the top pot.
+func (t *TexasHoldEm) updatePots() {
+	levels := []int{}
+	highest := 0
+	for _, player := range t.Players {
+		if player.TotalBet > highest {
+			highest = player.TotalBet
+		}
+
+		if player.Active && player.AllIn && player.TotalBet > 0 {
+			levels = append(levels, player.TotalBet)
+		}
+	}
+
+	sort.Ints(levels)
+	levels = append(levels, highest)
+
+	t.Pots = []Pot{}
+	previous := 0
+	for _, level := range levels {
+		if level <= previous {
+			continue
+		}
+
+		pot := Pot{Eligible: []string{}}
+		for _, player := range t.Players {
+			contribution := player.TotalBet - previous
+			if contribution > level-previous {
+				contribution = level - previous
+			}
+
+			if contribution > 0 {
+				pot.Amount += contribution
+			}
+
+			if player.Active && (!player.AllIn || player.TotalBet >= level) {
+				pot.Eligible = append(pot.Eligible, player.Name)
+			}
+		}
+
+		// Nobody left in the hand can win the chips above the highest live bet, they go to the pot below
+		if pot.Amount > 0 && len(pot.Eligible) == 0 && len(t.Pots) > 0 {
+			t.Pots[len(t.Pots)-1].Amount += pot.Amount
+		} else if pot.Amount > 0 {
+			t.Pots = append(t.Pots, pot)
+		}
+
+		previous = level
+	}
+}
+
+// awardPots splits every pot between the best eligible hands. The odd chips
+// go to the winners closest to the left of the button.
+func (t *TexasHoldEm) awardPots() {
+	t.updatePots()
+	t.showCards()
+	t.Winners = []Winner{}
+	for i := range t.Pots {
+		winners := t.getWinners(t.Pots[i].Eligible)
+		if len(winners) == 0 {
+			continue
+		}
+
+		share := t.Pots[i].Amount / len(winners)
+		oddChips := t.Pots[i].Amount % len(winners)
+		t.Pots[i].Winners = make([]string, len(winners))
+		for j, winner := range winners {
+			winner.Amount = share
+			if j < oddChips {
+				winner.Amount++
+			}
+
+			t.Pots[i].Winners[j] = winner.Name
+			t.Players[t.playerIndex(winner.Name)].Assets += winner.Amount
+			t.addWinner(winner)
+		}
+	}
+}
+
+// getWinners returns the candidates tied for the best hand, ordered by their
+// seat starting left of the button.
+func (t *TexasHoldEm) getWinners(candidates []string) []Winner {
+	winners := []Winner{}
+	bestScore := math.MaxInt32
+
+	for _, index := range t.seatOrder() {
+		player := t.Players[index]
+		if !player.Active || !contains(candidates, player.Name) {
+			continue
+		}
+
+		hand, score, rank := getBestHand(player.HoleCards, t.CommunityCards)
+		if score > bestScore {
+			continue
+		}
+
+		if score < bestScore {
+			bestScore = score
+			winners = winners[:0]
+		}
+
+		winners = append(winners, Winner{
+			Name: player.Name,
+			Rank: rank,
+			Hand: hand,
+		})
+	}
+
+	return winners
+}
+
+// addWinner adds the winnings to the winners list, merging the pots won by the same player.
+func (t *TexasHoldEm) addWinner(winner Winner) {
+	for i := range t.Winners {
+		if t.Winners[i].Name == winner.Name {
+			t.Winners[i].Amount += winner.Amount
+			return
+		}
+	}
+
+	t.Winners = append(t.Winners, winner)
+}
+
+// seatOrder returns the player indexes starting from the first seat left of the button.
+func (t *TexasHoldEm) seatOrder() []int {
+	order := make([]int, len(t.Players))
+	for i := range order {
+		order[i] = (t.Dealer + 1 + i) % len(t.Players)
+	}
+
+	return order
+}
+
+// PotTotal returns the sum of all the pots.
+func (t *TexasHoldEm) PotTotal() int {
+	total := 0
+	for _, pot := range t.Pots {
+		total += pot.Amount
+	}
+
+	return total
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,174 @@
+package texas
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var InvalidSeatErr = errors.New("Invalid seat")
+var SeatTakenErr = errors.New("Seat is taken")
+
+// ChooseSeat moves the player to another free seat, the seats can only be changed before the game starts.
+func (t *TexasHoldEm) ChooseSeat(username string, seat int) error {
+	index := t.playerIndex(username)
+	if index == -1 {
+		return PlayerNotInGameErr
+	}
+
+	if t.gameStarted {
+		return GameStillInProgressErr
+	}
+
+	if seat < 0 || seat >= t.Config.MaxPlayers {
+		return fmt.Errorf("%w: the seats are numbered from 0 to %d", InvalidSeatErr, t.Config.MaxPlayers-1)
+	}
+
+	if other := t.seatIndex(seat); other != -1 && other != index {
+		return SeatTakenErr
+	}
+
+	t.Players[index].Seat = seat
+	sort.SliceStable(t.Players, func(i, j int) bool {
+		return t.Players[i].Seat < t.Players[j].Seat
+	})
+
+	return nil
+}
+
+// SitOut makes the player skip the next hands, zero hands means until they come back.
+// A hand which is already dealt is played as usual.
+func (t *TexasHoldEm) SitOut(username string, hands int) error {
+	index := t.playerIndex(username)
+	if index == -1 || t.Players[index].Left {
+		return PlayerNotInGameErr
+	}
+
+	if hands < 0 {
+		return fmt.Errorf("%w: cannot sit out %d hands", InvalidActionErr, hands)
+	}
+
+	if t.Config.IsTournament() {
+		return fmt.Errorf("%w: cannot sit out of a tournament", InvalidActionErr)
+	}
+
+	t.Players[index].SittingOut = true
+	t.Players[index].SitOutHands = hands
+	return nil
+}
+
+// SitIn deals the player back in starting with the next hand.
+func (t *TexasHoldEm) SitIn(username string) error {
+	index := t.playerIndex(username)
+	if index == -1 || t.Players[index].Left {
+		return PlayerNotInGameErr
+	}
+
+	if t.Players[index].Assets < t.minimumStack() {
+		return fmt.Errorf("%w: top up to at least %d chips to sit in", NotEnoughMoneyErr, t.minimumStack())
+	}
+
+	t.Players[index].SittingOut = false
+	t.Players[index].SitOutHands = 0
+	return nil
+}
+
+// IsWaiting returns true if the game is paused until enough players sit back in.
+func (t *TexasHoldEm) IsWaiting() bool {
+	return t.waiting
+}
+
+// seatPlayer seats the player at the lowest free seat, the seats after it are moved along.
+func (t *TexasHoldEm) seatPlayer(player Player) {
+	for t.seatIndex(player.Seat) != -1 {
+		player.Seat++
+	}
+
+	index := sort.Search(len(t.Players), func(i int) bool {
+		return t.Players[i].Seat > player.Seat
+	})
+
+	t.Players = append(t.Players, Player{})
+	copy(t.Players[index+1:], t.Players[index:])
+	t.Players[index] = player
+	if !t.gameStarted {
+		return
+	}
+
+	// Keep the positions pointing at the same players
+	for _, position := range []*int{&t.Dealer, &t.SmallBlind, &t.BigBlind, &t.CurrentPlayer, &t.roundStart} {
+		if *position >= index {
+			*position++
+		}
+	}
+}
+
+// seatIndex returns the index of the player sitting in the seat.
+func (t *TexasHoldEm) seatIndex(seat int) int {
+	for i, player := range t.Players {
+		if player.Seat == seat {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// playing counts the players who will be dealt into the next hand.
+func (t *TexasHoldEm) playing() int {
+	count := 0
+	for _, player := range t.Players {
+		if player.dealtIn() {
+			count++
+		}
+	}
+
+	return count
+}
+
+// nextDealtIn returns the next player after the index who is dealt into the hand.
+func (t *TexasHoldEm) nextDealtIn(index int) int {
+	for i := 1; i <= len(t.Players); i++ {
+		next := (index + i) % len(t.Players)
+		if t.Players[next].Active {
+			return next
+		}
+	}
+
+	return index
+}
+
+// countSitOuts counts the hand the players sat out, the ones who sat out enough hands come back.
+func (t *TexasHoldEm) countSitOuts() {
+	for i := range t.Players {
+		player := &t.Players[i]
+		if !player.SittingOut || player.SitOutHands == 0 || len(player.HoleCards) > 0 {
+			continue
+		}
+
+		player.SitOutHands--
+		if player.SitOutHands == 0 {
+			player.SittingOut = false
+		}
+	}
+}
+
+// sitOutShortStacks sits out the players who cannot post the blinds.
+func (t *TexasHoldEm) sitOutShortStacks() {
+	for i := range t.Players {
+		if !t.Players[i].SittingOut && t.Players[i].Assets < t.minimumStack() {
+			t.Players[i].SittingOut = true
+			t.Players[i].SitOutHands = 0
+		}
+	}
+}
+
+// minimumStack is the stack needed to post the big blind and the ante.
+func (t *TexasHoldEm) minimumStack() int {
+	return t.Config.BigBlind + t.Config.Ante
+}
+
+// dealtIn returns true if the player gets cards in the next hand.
+func (p Player) dealtIn() bool {
+	return !p.Left && !p.SittingOut && p.Assets > 0
+}
@@ -2,6 +2,7 @@ package texas
 
 import (
 	"errors"
+	"fmt"
 	"math"
 
 	"github.com/chehsunliu/poker"
@@ -23,45 +24,73 @@ const (
 	None  PokerAction = "none"
 	Call  PokerAction = "call"
 	Raise PokerAction = "raise"
+	Bet   PokerAction = "bet"
 	Check PokerAction = "check"
 	Fold  PokerAction = "fold"
+	AllIn PokerAction = "allin"
 )
 
 var actionMap = map[string]PokerAction{
 	"none":  None,
 	"call":  Call,
 	"raise": Raise,
+	"bet":   Bet,
 	"check": Check,
 	"fold":  Fold,
+	"allin": AllIn,
 }
 
 var NotEnoughMoneyErr = errors.New("Not enough money")
 var WrongTurnErr = errors.New("Wrong turn")
 var InvalidActionErr = errors.New("Wrong action")
+var InvalidBetSizeErr = errors.New("Invalid bet size")
 var NotEnoughPlayersErr = errors.New("Not enough players")
 var GameStillInProgressErr = errors.New("Game still in progress")
+var HandOverErr = errors.New("Hand is over")
 var PlayerNotInGameErr = errors.New("Player not in game")
 var OwnTurnDisconnectErr = errors.New("Own turn disconnection")
 var DrawErr = errors.New("Internal error")
 var InvalidAssetErr = errors.New("Invalid asset number")
 var InternalErr = errors.New("Internal error")
 
-const RequiredPlayers = 3
-
 type TexasHoldEm struct {
-	deck           *poker.Deck
+	deck           []poker.Card
+	Config         TableConfig
 	CommunityCards []poker.Card
 	Players        []Player
 	Round          pokerRound
 	CurrentPlayer  int
+	Dealer         int
+	SmallBlind     int
+	BigBlind       int
+	HandNumber     int
 	ActiveBet      int
-	Pot            int
+	LastRaise      int
+	Pots           []Pot
+
+	// The tournament blind level, the number of entrants and the players in the order they were eliminated
+	Level      int
+	Entrants   int
+	Eliminated []string
+
+	// The seeds of the current hand and the commitment to the server seed of the next one
+	Seeds          ShuffleSeeds
+	NextCommitment string
+	nextSeed       string
+	source         DeckSource
+
+	// The moves the player to act can make, only they see them in their sanitized state
+	Legal *Legal
 
 	gameStarted bool
+	waiting     bool
+	managed     bool
+	roundStart  int
+	raiseCount  int
+	HandOver    bool
 	GameOver    bool
-	GameWinner  string
-	BestRank    string
-	BestHand    []poker.Card
+	Winners     []Winner
+	history     HandHistory
 }
 
 type Player struct {
@@ -69,30 +98,52 @@ type Player struct {
 	HoleCards []poker.Card
 	Assets    int
 	Bet       int
+	TotalBet  int
 	Action    PokerAction
 	Active    bool
+	AllIn     bool
+	Left      bool
+
+	// The seat number and the hands left to sit out, zero hands means until the player comes back
+	Seat        int
+	SittingOut  bool
+	SitOutHands int
+
+	// The entropy the player adds to the shuffle
+	Seed string
 }
 
-func NewTexasHoldEm() *TexasHoldEm {
-	return &TexasHoldEm{
-		deck: poker.NewDeck(),
+func NewTexasHoldEm(config TableConfig) *TexasHoldEm {
+	t := &TexasHoldEm{
+		Config: config,
 	}
+
+	// The first hand is committed to before anyone sits down, dealing retries if it fails
+	t.commitNextSeed()
+	return t
 }
 
 func (t *TexasHoldEm) AddPlayer(username string, assets int) error {
-	if assets < 0 {
+	if assets < t.Config.MinBuyIn || assets > t.Config.MaxBuyIn {
 		return InvalidAssetErr
 	}
 
 	for _, player := range t.Players {
 		if player.Name == username {
-			// This means the player is reconnecting, so we don't want to add them again
-			// but they should be folding for the rest of the game
+			// This means the player is reconnecting to their seat, so we don't want to add them again
 			return nil
 		}
 	}
 
-	t.Players = append(t.Players, Player{
+	if t.gameStarted && t.Config.IsTournament() {
+		return GameStillInProgressErr
+	}
+
+	if len(t.Players) >= t.Config.MaxPlayers {
+		return TableFullErr
+	}
+
+	t.seatPlayer(Player{
 		Name:   username,
 		Assets: assets,
 	})
@@ -100,19 +151,136 @@ func (t *TexasHoldEm) AddPlayer(username string, assets int) error {
 	return nil
 }
 
+// TopUp adds chips to the player's stack between hands, the stack can't go over the maximum buy-in.
+// A player who wasn't dealt into the current hand can top up at any time.
+func (t *TexasHoldEm) TopUp(username string, amount int) error {
+	index := t.playerIndex(username)
+	if index == -1 || t.Players[index].Left {
+		return PlayerNotInGameErr
+	}
+
+	if t.Config.IsTournament() {
+		return fmt.Errorf("%w: there are no rebuys in a tournament", InvalidActionErr)
+	}
+
+	player := &t.Players[index]
+	if t.gameStarted && !t.HandOver && len(player.HoleCards) > 0 {
+		return GameStillInProgressErr
+	}
+
+	stack := player.Assets + amount
+	if amount <= 0 || stack < t.Config.MinBuyIn || stack > t.Config.MaxBuyIn {
+		return fmt.Errorf("%w: the stack must be between %d and %d", InvalidAssetErr, t.Config.MinBuyIn, t.Config.MaxBuyIn)
+	}
+
+	player.Assets = stack
+	return nil
+}
+
 func (t *TexasHoldEm) StartGame() error {
 	if t.gameStarted {
 		return GameStillInProgressErr
 	}
 
-	if len(t.Players) < RequiredPlayers {
+	if t.playing() < t.RequiredPlayers() {
+		return NotEnoughPlayersErr
+	}
+
+	// A sit-and-go starts once every seat is taken
+	if t.Config.IsTournament() && !t.managed && len(t.Players) < t.Config.MaxPlayers {
 		return NotEnoughPlayersErr
 	}
 
 	t.gameStarted = true
+	t.Entrants = len(t.Players)
+	t.Dealer = 0
+	for !t.Players[t.Dealer].dealtIn() {
+		t.Dealer++
+	}
+
+	return t.dealHand()
+}
+
+// NextHand removes the players who left, moves the button and deals a new hand.
+// The players who cannot post the blinds sit out until they top up. The game is
+// over when there are not enough players left, it waits if too many of them are sitting out.
+// In a tournament the busted players are eliminated and the blinds follow the level.
+func (t *TexasHoldEm) NextHand() error {
+	if !t.HandOver {
+		return GameStillInProgressErr
+	}
+
+	if !t.waiting {
+		t.countSitOuts()
+	}
+
+	if t.Config.IsTournament() {
+		t.eliminate()
+		t.applyLevel()
+	} else {
+		t.sitOutShortStacks()
+	}
+
+	// The button goes to the next player who is dealt in
+	nextDealer := ""
+	for i := 1; i <= len(t.Players); i++ {
+		player := t.Players[(t.Dealer+i)%len(t.Players)]
+		if player.dealtIn() {
+			nextDealer = player.Name
+			break
+		}
+	}
+
+	seated := []Player{}
+	for _, player := range t.Players {
+		if !player.Left && !t.isEliminated(player.Name) {
+			seated = append(seated, player)
+		}
+	}
+
+	t.Players = seated
+	if len(t.Players) < t.RequiredPlayers() {
+		t.GameOver = true
+		return NotEnoughPlayersErr
+	}
+
+	t.waiting = t.playing() < t.RequiredPlayers()
+	if t.waiting {
+		return NotEnoughPlayersErr
+	}
+
+	t.Dealer = t.playerIndex(nextDealer)
+	return t.dealHand()
+}
+
+func (t *TexasHoldEm) dealHand() error {
+	if err := t.useSeeds(); err != nil {
+		return err
+	}
+
+	deck, err := t.deckSource().Deck(t.Seeds)
+	if err != nil {
+		return err
+	}
+
+	t.deck = deck
+	t.CommunityCards = []poker.Card{}
+	t.Winners = []Winner{}
+	t.HandOver = false
+	t.HandNumber++
 	t.Round = PreFlop
 	for i := range t.Players {
-		cards, err := safeDraw(t.deck, 2)
+		t.Players[i].HoleCards = []poker.Card{}
+		t.Players[i].Active = false
+		t.Players[i].AllIn = false
+		t.Players[i].Bet = 0
+		t.Players[i].TotalBet = 0
+		t.Players[i].Action = None
+		if !t.Players[i].dealtIn() {
+			continue
+		}
+
+		cards, err := t.draw(2)
 		if err != nil {
 			return err
 		}
@@ -120,33 +288,50 @@ func (t *TexasHoldEm) StartGame() error {
 		t.Players[i].HoleCards = make([]poker.Card, 2)
 		copy(t.Players[i].HoleCards, cards)
 		t.Players[i].Active = true
-		t.Players[i].Bet = 0
 	}
 
-	bigBlind := len(t.Players) - 1
-	if t.Players[bigBlind].Assets < 2 {
-		return NotEnoughMoneyErr
-	}
+	t.startHistory()
 
-	t.Players[bigBlind].Bet = 2
-	t.Players[bigBlind].Assets -= 2
+	// The antes are dead money, they don't count towards the bet
+	if t.Config.Ante > 0 {
+		for i := range t.Players {
+			if !t.Players[i].Active {
+				continue
+			}
 
-	smallBlind := bigBlind - 1
-	if t.Players[smallBlind].Assets < 1 {
-		return NotEnoughMoneyErr
+			ante := t.commit(i, t.Config.Ante)
+			t.Players[i].Bet = 0
+			t.record(i, PostAnte, ante)
+		}
 	}
 
-	t.Players[smallBlind].Bet = 1
-	t.Players[smallBlind].Assets -= 1
+	// Short stacked players post what they have and are all-in, the players sitting out are skipped.
+	// Heads-up the button posts the small blind, so it acts first preflop and last after the flop
+	t.SmallBlind = t.nextDealtIn(t.Dealer)
+	if t.playing() == MinimumPlayers {
+		t.SmallBlind = t.Dealer
+	}
 
-	t.Pot = 3
-	t.ActiveBet = 2
+	t.BigBlind = t.nextDealtIn(t.SmallBlind)
+	t.record(t.SmallBlind, PostSmallBlind, t.commit(t.SmallBlind, t.Config.SmallBlind))
+	t.record(t.BigBlind, PostBigBlind, t.commit(t.BigBlind, t.Config.BigBlind))
 
+	t.roundStart = (t.BigBlind + 1) % len(t.Players)
+	t.CurrentPlayer = t.firstToAct()
+	t.ActiveBet = t.Config.BigBlind
+	t.LastRaise = t.Config.BigBlind
+	t.raiseCount = 1
+	t.updatePots()
 	return nil
 }
 
-func (t *TexasHoldEm) AdvanceState(username string, action PokerAction) error {
-	if len(t.Players) < RequiredPlayers {
+// AdvanceState performs the player's action, the amount is the total bet the
+// player bets or raises to and is ignored for the other actions. Betting or
+// raising by zero is the minimum. A bet opens a street, a raise increases the
+// bet. Calling when nothing is owed is the same as checking. The round ends
+// once every player who can act has acted and matched the last bet.
+func (t *TexasHoldEm) AdvanceState(username string, action PokerAction, amount int) error {
+	if len(t.Players) < t.RequiredPlayers() {
 		return NotEnoughPlayersErr
 	}
 
@@ -161,6 +346,10 @@ func (t *TexasHoldEm) AdvanceState(username string, action PokerAction) error {
 		return PlayerNotInGameErr
 	}
 
+	if t.HandOver {
+		return HandOverErr
+	}
+
 	if playerIndex != t.CurrentPlayer {
 		return WrongTurnErr
 	}
@@ -169,40 +358,61 @@ func (t *TexasHoldEm) AdvanceState(username string, action PokerAction) error {
 		return InvalidActionErr
 	}
 
+	if t.Players[playerIndex].AllIn {
+		return InvalidActionErr
+	}
+
+	committed := t.Players[playerIndex].TotalBet
 	switch action {
 	case Call:
-		if t.Players[playerIndex].Assets < t.ActiveBet {
-			return NotEnoughMoneyErr
+		// Calling without enough chips puts the player all-in
+		t.commit(playerIndex, t.ActiveBet-t.Players[playerIndex].Bet)
+		t.Players[playerIndex].Action = Call
+
+	case Bet, Raise:
+		if (action == Bet) != (t.ActiveBet == 0) {
+			if action == Bet {
+				return fmt.Errorf("%w: there is a bet to raise", InvalidActionErr)
+			}
+
+			return fmt.Errorf("%w: there is no bet to raise, bet instead", InvalidActionErr)
 		}
 
-		t.Pot += t.ActiveBet - t.Players[playerIndex].Bet
-		t.Players[playerIndex].Assets -= t.ActiveBet - t.Players[playerIndex].Bet
-		t.Players[playerIndex].Bet = t.ActiveBet
-		t.Players[playerIndex].Action = Call
+		if amount == 0 {
+			amount, _ = t.RaiseBounds(playerIndex)
+		}
 
-	case Raise:
-		if t.Players[playerIndex].Assets < t.ActiveBet+2 {
-			return NotEnoughMoneyErr
+		if err := t.validateRaise(playerIndex, amount); err != nil {
+			return err
 		}
 
-		t.Pot += t.ActiveBet - t.Players[playerIndex].Bet + 2
-		t.Players[playerIndex].Assets -= t.ActiveBet + 2 - t.Players[playerIndex].Bet
-		t.Players[playerIndex].Bet = t.ActiveBet + 2
-		t.Players[playerIndex].Action = Raise
+		t.raiseTo(playerIndex, amount)
+		t.Players[playerIndex].Action = action
 
-	case Check:
-		if t.Round == PreFlop {
-			return InvalidActionErr
+	case AllIn:
+		if t.Players[playerIndex].Assets == 0 {
+			return NotEnoughMoneyErr
 		}
 
-		if playerIndex != 0 {
-			for i := playerIndex - 1; i >= 0; i-- {
-				if t.Players[i].Action != Check {
-					return InvalidActionErr
-				}
+		allIn := t.Players[playerIndex].Bet + t.Players[playerIndex].Assets
+		if allIn > t.ActiveBet && t.raiseCapped() {
+			return fmt.Errorf("%w: the betting is capped at %d raises", InvalidBetSizeErr, fixedLimitCap)
+		}
+
+		if allIn > t.ActiveBet && t.Config.Structure != NoLimit {
+			if _, maxRaise := t.RaiseBounds(playerIndex); allIn > maxRaise {
+				return fmt.Errorf("%w: %s allows raising to at most %d", InvalidBetSizeErr, t.Config.Structure, maxRaise)
 			}
 		}
 
+		t.raiseTo(playerIndex, allIn)
+		t.Players[playerIndex].Action = AllIn
+
+	case Check:
+		if !t.canCheck(playerIndex) {
+			return InvalidActionErr
+		}
+
 		t.Players[playerIndex].Action = Check
 
 	case Fold:
@@ -210,15 +420,64 @@ func (t *TexasHoldEm) AdvanceState(username string, action PokerAction) error {
 		t.Players[playerIndex].Active = false
 	}
 
-	var looped bool
-	t.CurrentPlayer, looped = t.getNextPlayer(t.CurrentPlayer)
-	if !looped {
+	t.record(playerIndex, action, t.Players[playerIndex].TotalBet-committed)
+	t.updatePots()
+	if t.roundOver() {
+		return t.nextRound()
+	}
+
+	t.CurrentPlayer = t.getNextPlayer(t.CurrentPlayer)
+	return nil
+}
+
+// Timeout acts for the player who ran out of time, they check if nothing is
+// owed and fold otherwise. The action taken is returned.
+func (t *TexasHoldEm) Timeout() (PokerAction, error) {
+	if !t.gameStarted || t.HandOver {
+		return None, HandOverErr
+	}
+
+	player := t.Players[t.CurrentPlayer]
+	if player.Bet < t.ActiveBet {
+		return Fold, t.AdvanceState(player.Name, Fold, 0)
+	}
+
+	return Check, t.AdvanceState(player.Name, Check, 0)
+}
+
+func (t *TexasHoldEm) nextRound() error {
+	playersActive := 0
+	lastActivePlayer := -1
+	playersActing := 0
+	for i, player := range t.Players {
+		if player.Active {
+			playersActive++
+			lastActivePlayer = i
+			if !player.AllIn {
+				playersActing++
+			}
+		}
+	}
+
+	if playersActive == 1 {
+		t.HandOver = true
+		t.updatePots()
+		t.Players[lastActivePlayer].Assets += t.PotTotal()
+		for i := range t.Pots {
+			t.Pots[i].Winners = []string{t.Players[lastActivePlayer].Name}
+		}
+
+		t.Winners = []Winner{{
+			Name:   t.Players[lastActivePlayer].Name,
+			Amount: t.PotTotal(),
+			Rank:   "Last man standing",
+		}}
 		return nil
 	}
 
 	switch t.Round {
 	case PreFlop:
-		cards, err := safeDraw(t.deck, 3)
+		cards, err := t.draw(3)
 		if err != nil {
 			return err
 		}
@@ -227,7 +486,7 @@ func (t *TexasHoldEm) AdvanceState(username string, action PokerAction) error {
 		t.Round = Flop
 
 	case Flop:
-		card, err := safeDraw(t.deck, 1)
+		card, err := t.draw(1)
 		if err != nil {
 			return err
 		}
@@ -236,7 +495,7 @@ func (t *TexasHoldEm) AdvanceState(username string, action PokerAction) error {
 		t.Round = Turn
 
 	case Turn:
-		card, err := safeDraw(t.deck, 1)
+		card, err := t.draw(1)
 		if err != nil {
 			return err
 		}
@@ -245,87 +504,142 @@ func (t *TexasHoldEm) AdvanceState(username string, action PokerAction) error {
 		t.Round = River
 
 	case River:
-		t.GameOver = true
-		winner, rank, hand, err := t.getWinner()
-		t.GameWinner = winner
-		t.BestRank = rank
-		t.BestHand = hand
-		return err
+		t.HandOver = true
+		t.awardPots()
+		return nil
 	}
 
-	playersActive := 0
-	lastActivePlayer := -1
-	for i, player := range t.Players {
-		if player.Active {
-			playersActive++
-			lastActivePlayer = i
+	for i := range t.Players {
+		t.Players[i].Bet = 0
+		if t.Players[i].Active && !t.Players[i].AllIn {
+			t.Players[i].Action = None
 		}
 	}
 
-	if playersActive == 1 {
-		t.GameOver = true
-		t.GameWinner = t.Players[lastActivePlayer].Name
-		t.BestRank = "Last man standing"
-		return nil
+	t.ActiveBet = 0
+	t.LastRaise = t.Config.BigBlind
+	t.raiseCount = 0
+	t.roundStart = (t.Dealer + 1) % len(t.Players)
+	t.CurrentPlayer = t.firstToAct()
+
+	// Nobody can bet anymore, deal the rest of the board
+	if playersActing < 2 {
+		return t.nextRound()
 	}
 
-	for i := range t.Players {
-		if t.Players[i].Active {
-			t.Players[i].Action = None
-		}
+	return nil
+}
+
+// commit moves chips from the player's stack into the pot, going all-in
+// if the player cannot cover the amount. It returns the amount committed.
+func (t *TexasHoldEm) commit(index int, amount int) int {
+	player := &t.Players[index]
+	if amount >= player.Assets {
+		amount = player.Assets
+		player.AllIn = true
 	}
 
-	smallBlind, _ := t.getNextPlayer(0)
-	if t.Players[smallBlind].Assets < 1 {
-		return NotEnoughMoneyErr
+	player.Assets -= amount
+	player.Bet += amount
+	player.TotalBet += amount
+	return amount
+}
+
+// FindPlayer returns the player seated under the username.
+func (t *TexasHoldEm) FindPlayer(username string) (Player, bool) {
+	index := t.playerIndex(username)
+	if index == -1 {
+		return Player{}, false
 	}
 
-	t.Players[smallBlind].Bet = 1
-	t.Players[smallBlind].Assets -= 1
+	return t.Players[index], true
+}
 
-	bigBlind, _ := t.getNextPlayer(smallBlind)
-	if t.Players[bigBlind].Assets < 2 {
-		return NotEnoughMoneyErr
+func (t *TexasHoldEm) playerIndex(username string) int {
+	for i, player := range t.Players {
+		if player.Name == username {
+			return i
+		}
 	}
 
-	t.Players[bigBlind].Bet = 2
-	t.Players[bigBlind].Assets -= 2
+	return -1
+}
 
-	t.ActiveBet = 2
-	return nil
+// firstToAct returns the first player who can act starting from the seat
+// that opens the betting round.
+func (t *TexasHoldEm) firstToAct() int {
+	for i := 0; i < len(t.Players); i++ {
+		index := (t.roundStart + i) % len(t.Players)
+		if t.Players[index].Active && !t.Players[index].AllIn {
+			return index
+		}
+	}
+
+	return -1
 }
 
-func (t *TexasHoldEm) getNextPlayer(current int) (int, bool) {
-	numPlayers := len(t.Players)
-	looped := false
-	for i := 1; i < numPlayers; i++ {
-		index := (current + i) % numPlayers
-		if index == 0 {
-			looped = true
+// getNextPlayer returns the next player after the current one who can act.
+func (t *TexasHoldEm) getNextPlayer(current int) int {
+	for i := 1; i < len(t.Players); i++ {
+		index := (current + i) % len(t.Players)
+		if t.Players[index].Active && !t.Players[index].AllIn {
+			return index
 		}
+	}
 
-		if t.Players[index].Active {
-			return index, looped
+	return -1
+}
+
+// roundOver returns true if the betting round is over: a single player is left
+// or every player who can act has acted and matched the bet since the last
+// aggression. A single player left to act who has matched the bet doesn't act
+// against the players who are all-in.
+func (t *TexasHoldEm) roundOver() bool {
+	active, acting, pending := 0, 0, 0
+	matched := true
+	for _, player := range t.Players {
+		if !player.Active {
+			continue
+		}
+
+		active++
+		if player.AllIn {
+			continue
 		}
 
-		if index == numPlayers-1 {
-			looped = true
+		acting++
+		if player.Bet < t.ActiveBet {
+			matched = false
+			pending++
+		} else if player.Action == None {
+			pending++
 		}
 	}
 
-	return -1, looped
+	if active < 2 || pending == 0 {
+		return true
+	}
+
+	return acting == 1 && matched
 }
 
+// SanitizeState hides the hole cards the user isn't allowed to see, the cards
+// shown at the showdown are revealed. The server seed is never revealed, together
+// with the player seeds it rebuilds the deck including the mucked hole cards.
+// The legal actions are only given to the player to act. Spectators pass an empty username.
 func (t TexasHoldEm) SanitizeState(username string) *TexasHoldEm {
-	if t.GameOver {
-		return &t
+	sanitized := t
+	sanitized.Seeds.ServerSeed = ""
+
+	sanitized.Legal = nil
+	if legal := t.LegalActions(username); len(legal.Actions) > 0 {
+		sanitized.Legal = &legal
 	}
 
-	sanitized := t
 	sanitized.Players = make([]Player, len(t.Players))
 	for i, player := range t.Players {
 		sanitized.Players[i] = player
-		if player.Name != username {
+		if player.Name != username && !(t.HandOver && t.isShown(player.Name)) {
 			sanitized.Players[i].HoleCards = []poker.Card{}
 		}
 	}
@@ -333,42 +647,41 @@ func (t TexasHoldEm) SanitizeState(username string) *TexasHoldEm {
 	return &sanitized
 }
 
+// Disconnect folds the player's hand, the player is removed from the table
+// before the next hand is dealt. Before the game starts the seat is freed at once.
+// A player who is all-in keeps their hand until the showdown.
 func (t *TexasHoldEm) Disconnect(username string) error {
-	var found bool
-	var index int
-	for i, player := range t.Players {
-		if player.Name == username {
-			found = true
-			index = i
-		}
+	index := t.playerIndex(username)
+	if index == -1 {
+		return PlayerNotInGameErr
 	}
 
-	if !found {
-		return PlayerNotInGameErr
+	if !t.gameStarted {
+		t.Players = append(t.Players[:index], t.Players[index+1:]...)
+		return nil
+	}
+
+	t.Players[index].Left = true
+	if t.HandOver || !t.Players[index].Active || t.Players[index].AllIn {
+		return nil
 	}
 
 	if index == t.CurrentPlayer {
-		if err := t.AdvanceState(username, Fold); err != nil {
+		if err := t.AdvanceState(username, Fold, 0); err != nil {
 			return err
 		}
 
 		return OwnTurnDisconnectErr
 	}
 
-	if len(t.Players) == 1 {
-		t.GameOver = true
-		t.GameWinner = t.Players[0].Name
-		t.BestRank = "Last man standing..."
-		return nil
-	}
-
-	if len(t.Players) == 0 {
-		t.GameOver = true
-		t.GameWinner = "No one"
-		t.BestRank = "No one won..."
-		return nil
+	t.Players[index].Action = Fold
+	t.Players[index].Active = false
+	t.record(index, Fold, 0)
+	if t.roundOver() {
+		return t.nextRound()
 	}
 
+	t.updatePots()
 	return nil
 }
 
@@ -376,31 +689,8 @@ func (t *TexasHoldEm) IsGameOver() bool {
 	return t.GameOver
 }
 
-func (t *TexasHoldEm) getWinner() (string, string, []poker.Card, error) {
-	if !t.GameOver {
-		return "", "", []poker.Card{}, GameStillInProgressErr
-	}
-
-	var bestRank string
-	var bestHand []poker.Card
-	var bestPlayer string
-	bestScore := math.MaxInt32
-
-	for _, player := range t.Players {
-		if !player.Active {
-			continue
-		}
-
-		hand, score, rank := getBestHand(player.HoleCards, t.CommunityCards)
-		if score < bestScore {
-			bestScore = score
-			bestRank = rank
-			bestHand = hand
-			bestPlayer = player.Name
-		}
-	}
-
-	return bestPlayer, bestRank, bestHand, nil
+func (t *TexasHoldEm) IsHandOver() bool {
+	return t.HandOver
 }
 
 func (t *TexasHoldEm) ShouldBeDisbanded() bool {
@@ -412,20 +702,15 @@ func DecodeAction(text string) (PokerAction, bool) {
 	return val, ok
 }
 
-func safeDraw(deck *poker.Deck, n int) ([]poker.Card, error) {
-	if deck == nil {
+// draw takes the cards from the top of the deck.
+func (t *TexasHoldEm) draw(n int) ([]poker.Card, error) {
+	if len(t.deck) < n {
 		return []poker.Card{}, DrawErr
 	}
 
 	cards := make([]poker.Card, n)
-	for i := 0; i < n; i++ {
-		if deck.Empty() {
-			return []poker.Card{}, DrawErr
-		}
-
-		cards[i] = deck.Draw(1)[0]
-	}
-
+	copy(cards, t.deck[:n])
+	t.deck = t.deck[n:]
 	return cards, nil
 }
 
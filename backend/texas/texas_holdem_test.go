@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/chehsunliu/poker"
@@ -11,7 +12,7 @@ import (
 
 // testGame creates a test game.
 func testGame() *TexasHoldEm {
-	texas := NewTexasHoldEm()
+	texas := NewTexasHoldEm(DefaultConfig())
 	if err := texas.AddPlayer("Player 0", 100); err != nil {
 		os.Exit(1)
 	}
@@ -31,7 +32,7 @@ func testGame() *TexasHoldEm {
 	return texas
 }
 
-// TestDetermineWinner tests the getWinner function.
+// TestDetermineWinner tests the getWinners function.
 func TestDetermineWinner(t *testing.T) {
 	tt := []struct {
 		name     string
@@ -40,7 +41,7 @@ func TestDetermineWinner(t *testing.T) {
 		hole1    []poker.Card
 		hole2    []poker.Card
 		bestRank string
-		winner   string
+		winners  []string
 	}{
 		{
 			name: "p0: four of a kind",
@@ -55,7 +56,7 @@ func TestDetermineWinner(t *testing.T) {
 			hole1:    []poker.Card{poker.NewCard("2h"), poker.NewCard("2s")},
 			hole2:    []poker.Card{poker.NewCard("3h"), poker.NewCard("3s")},
 			bestRank: "Four of a Kind",
-			winner:   "Player 0",
+			winners:  []string{"Player 0"},
 		},
 		{
 			name: "p1: full house",
@@ -70,7 +71,22 @@ func TestDetermineWinner(t *testing.T) {
 			hole1:    []poker.Card{poker.NewCard("2d"), poker.NewCard("2c")},
 			hole2:    []poker.Card{poker.NewCard("3h"), poker.NewCard("4s")},
 			bestRank: "Full House",
-			winner:   "Player 1",
+			winners:  []string{"Player 1"},
+		},
+		{
+			name: "all players: straight on the board",
+			comunity: []poker.Card{
+				poker.NewCard("Ts"),
+				poker.NewCard("Jh"),
+				poker.NewCard("Qc"),
+				poker.NewCard("Kd"),
+				poker.NewCard("As"),
+			},
+			hole0:    []poker.Card{poker.NewCard("2h"), poker.NewCard("3s")},
+			hole1:    []poker.Card{poker.NewCard("2d"), poker.NewCard("2c")},
+			hole2:    []poker.Card{poker.NewCard("3h"), poker.NewCard("4s")},
+			bestRank: "Straight",
+			winners:  []string{"Player 1", "Player 2", "Player 0"},
 		},
 	}
 
@@ -81,20 +97,22 @@ func TestDetermineWinner(t *testing.T) {
 			texas.Players[0].HoleCards = tc.hole0
 			texas.Players[1].HoleCards = tc.hole1
 			texas.Players[2].HoleCards = tc.hole2
-			texas.GameOver = true
+			texas.HandOver = true
 
-			player, rank, hand, err := texas.getWinner()
-			if err != nil {
-				t.Fatal(err)
+			winners := texas.getWinners([]string{"Player 0", "Player 1", "Player 2"})
+			if len(winners) != len(tc.winners) {
+				t.Fatalf("expected %d winners, got %v", len(tc.winners), winners)
 			}
 
-			if player != tc.winner {
-				t.Errorf("%v", hand)
-				t.Errorf("expected winner to be %s, got %s", tc.winner, player)
-			}
+			for i, winner := range winners {
+				if winner.Name != tc.winners[i] {
+					t.Errorf("%v", winner.Hand)
+					t.Errorf("expected winner to be %s, got %s", tc.winners[i], winner.Name)
+				}
 
-			if rank != tc.bestRank {
-				t.Errorf("expected best rank to be %s, got %s", tc.bestRank, rank)
+				if winner.Rank != tc.bestRank {
+					t.Errorf("expected best rank to be %s, got %s", tc.bestRank, winner.Rank)
+				}
 			}
 		})
 	}
@@ -143,12 +161,13 @@ func TestSanitizeState(t *testing.T) {
 type testPlayerAction struct {
 	player string
 	action PokerAction
+	amount int
 }
 
 // handlePlayerAction handles a player action.
 func handlePlayerActions(texas *TexasHoldEm, moves []testPlayerAction) error {
 	for _, move := range moves {
-		err := texas.AdvanceState(move.player, move.action)
+		err := texas.AdvanceState(move.player, move.action, move.amount)
 		if err != nil {
 			return err
 		}
@@ -172,6 +191,9 @@ func TestNormalGame(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// After the flop the player left of the button acts first
+	threeCalls = append(threeCalls[1:], threeCalls[0])
+
 	if texas.Round != Flop {
 		t.Errorf("expected current state to be flop, got %s", texas.Round)
 	}
@@ -208,18 +230,18 @@ func TestNormalGame(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if !texas.IsGameOver() {
-		t.Errorf("expected game to be over, got not over")
+	if !texas.IsHandOver() {
+		t.Errorf("expected hand to be over, got not over")
 	}
 }
 
 // TestFolds tests a game of Texas Hold'em with folds.
 func TestFolds(t *testing.T) {
 	moves := []testPlayerAction{
-		{"Player 0", Fold},
-		{"Player 1", Call},
-		{"Player 2", Call},
-		{"Player 0", Call},
+		{"Player 0", Fold, 0},
+		{"Player 1", Call, 0},
+		{"Player 2", Call, 0},
+		{"Player 0", Call, 0},
 	}
 
 	if err := handlePlayerActions(testGame(), moves); err == nil || !errors.Is(err, WrongTurnErr) {
@@ -227,9 +249,9 @@ func TestFolds(t *testing.T) {
 	}
 
 	moves = []testPlayerAction{
-		{"Player 0", Call},
-		{"Player 1", Fold},
-		{"Player 2", Fold},
+		{"Player 0", Call, 0},
+		{"Player 1", Fold, 0},
+		{"Player 2", Fold, 0},
 	}
 
 	texas := testGame()
@@ -237,18 +259,17 @@ func TestFolds(t *testing.T) {
 		t.Fatalf("expected no error, got %v", err)
 	}
 
-	if !texas.IsGameOver() {
-		t.Errorf("expected game to be over, got not over")
+	if !texas.IsHandOver() {
+		t.Errorf("expected hand to be over, got not over")
 	}
 
-	if texas.GameWinner != "Player 0" {
-		t.Errorf("expected player 0 to win, got %s", texas.GameWinner)
+	if len(texas.Winners) != 1 || texas.Winners[0].Name != "Player 0" {
+		t.Errorf("expected player 0 to win, got %v", texas.Winners)
 	}
 
 	moves = []testPlayerAction{
-		{"Player 0", Fold},
-		{"Player 1", Fold},
-		{"Player 2", Call},
+		{"Player 0", Fold, 0},
+		{"Player 1", Fold, 0},
 	}
 
 	texas = testGame()
@@ -256,20 +277,20 @@ func TestFolds(t *testing.T) {
 		t.Fatalf("expected no error, got %v", err)
 	}
 
-	if !texas.IsGameOver() {
-		t.Errorf("expected game to be over, got not over")
+	if !texas.IsHandOver() {
+		t.Errorf("expected hand to be over, got not over")
 	}
 
-	if texas.GameWinner != "Player 2" {
-		t.Errorf("expected player 2 to win, got %s", texas.GameWinner)
+	if len(texas.Winners) != 1 || texas.Winners[0].Name != "Player 2" {
+		t.Errorf("expected player 2 to win, got %v", texas.Winners)
 	}
 }
 
 // TestIllegalActions tests a game of Texas Hold'em with illegal actions.
 func TestIllegalActions(t *testing.T) {
 	moves := []testPlayerAction{
-		{"Player 0", Call},
-		{"Player 1", Check},
+		{"Player 0", Call, 0},
+		{"Player 1", Check, 0},
 	}
 
 	if err := handlePlayerActions(testGame(), moves); err == nil || !errors.Is(err, InvalidActionErr) {
@@ -277,8 +298,8 @@ func TestIllegalActions(t *testing.T) {
 	}
 
 	moves = []testPlayerAction{
-		{"Player 0", Call},
-		{"Player 0", Check},
+		{"Player 0", Call, 0},
+		{"Player 0", Check, 0},
 	}
 
 	if err := handlePlayerActions(testGame(), moves); err == nil || !errors.Is(err, WrongTurnErr) {
@@ -286,14 +307,1398 @@ func TestIllegalActions(t *testing.T) {
 	}
 
 	moves = []testPlayerAction{
-		{"Player 0", Call},
-		{"Player 1", Call},
-		{"Player 2", Call},
-		{"Player 0", Call},
-		{"Player 1", Check},
+		{"Player 0", Call, 0},
+		{"Player 1", Call, 0},
+		{"Player 2", Check, 0},
+		{"Player 1", Bet, 4},
+		{"Player 2", Check, 0},
 	}
 
 	if err := handlePlayerActions(testGame(), moves); err == nil || !errors.Is(err, InvalidActionErr) {
-		t.Errorf("expected illegal action error, got %v", err)
+		t.Errorf("expected illegal action error for checking a bet, got %v", err)
+	}
+
+	moves = []testPlayerAction{{"Player 0", Bet, 4}}
+	if err := handlePlayerActions(testGame(), moves); err == nil || !errors.Is(err, InvalidActionErr) {
+		t.Errorf("expected illegal action error for betting into the big blind, got %v", err)
+	}
+
+	moves = []testPlayerAction{
+		{"Player 0", Call, 0},
+		{"Player 1", Call, 0},
+		{"Player 2", Check, 0},
+		{"Player 1", Raise, 4},
+	}
+
+	if err := handlePlayerActions(testGame(), moves); err == nil || !errors.Is(err, InvalidActionErr) {
+		t.Errorf("expected illegal action error for raising without a bet, got %v", err)
+	}
+}
+
+// testGameWithStacks creates a test game where the players have the given stacks.
+func testGameWithStacks(t *testing.T, stacks ...int) *TexasHoldEm {
+	t.Helper()
+
+	config := DefaultConfig()
+	config.MinBuyIn = 1
+	config.MaxPlayers = len(stacks)
+	texas := NewTexasHoldEm(config)
+	for i, stack := range stacks {
+		if err := texas.AddPlayer(fmt.Sprintf("Player %d", i), stack); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := texas.StartGame(); err != nil {
+		t.Fatal(err)
+	}
+
+	return texas
+}
+
+// TestSidePots tests that all-in players create side pots.
+func TestSidePots(t *testing.T) {
+	texas := testGameWithStacks(t, 10, 30, 100)
+	moves := []testPlayerAction{
+		{"Player 0", AllIn, 0},
+		{"Player 1", AllIn, 0},
+		{"Player 2", Call, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	if !texas.IsHandOver() {
+		t.Fatalf("expected the board to be dealt out, got round %s", texas.Round)
+	}
+
+	if len(texas.CommunityCards) != 5 {
+		t.Errorf("expected community cards to have a length of 5, got %d", len(texas.CommunityCards))
+	}
+
+	if len(texas.Pots) != 2 {
+		t.Fatalf("expected 2 pots, got %d", len(texas.Pots))
+	}
+
+	if texas.Pots[0].Amount != 30 || len(texas.Pots[0].Eligible) != 3 {
+		t.Errorf("expected a main pot of 30 for 3 players, got %+v", texas.Pots[0])
+	}
+
+	if texas.Pots[1].Amount != 40 || len(texas.Pots[1].Eligible) != 2 {
+		t.Errorf("expected a side pot of 40 for 2 players, got %+v", texas.Pots[1])
+	}
+
+	total := 0
+	for _, player := range texas.Players {
+		total += player.Assets
+	}
+
+	if total != 140 {
+		t.Errorf("expected 140 chips on the table, got %d", total)
+	}
+}
+
+// TestAwardSidePots tests that every pot goes to the best eligible hand.
+func TestAwardSidePots(t *testing.T) {
+	texas := testGameWithStacks(t, 10, 30, 100)
+	moves := []testPlayerAction{
+		{"Player 0", AllIn, 0},
+		{"Player 1", AllIn, 0},
+		{"Player 2", Call, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	texas.CommunityCards = []poker.Card{
+		poker.NewCard("Qs"),
+		poker.NewCard("Qh"),
+		poker.NewCard("Ts"),
+		poker.NewCard("9s"),
+		poker.NewCard("2c"),
+	}
+	texas.Players[0].HoleCards = []poker.Card{poker.NewCard("Qd"), poker.NewCard("Qc")}
+	texas.Players[1].HoleCards = []poker.Card{poker.NewCard("Th"), poker.NewCard("Tc")}
+	texas.Players[2].HoleCards = []poker.Card{poker.NewCard("3h"), poker.NewCard("4d")}
+	for i := range texas.Players {
+		texas.Players[i].Assets = 0
+	}
+
+	texas.awardPots()
+
+	if texas.Players[0].Assets != 30 {
+		t.Errorf("expected player 0 to win the main pot of 30, got %d", texas.Players[0].Assets)
+	}
+
+	if texas.Players[1].Assets != 40 {
+		t.Errorf("expected player 1 to win the side pot of 40, got %d", texas.Players[1].Assets)
+	}
+
+	if len(texas.Winners) != 2 || texas.Winners[0].Name != "Player 0" {
+		t.Errorf("expected player 0 and player 1 to win, got %v", texas.Winners)
+	}
+}
+
+// TestDeadMoney tests that the chips of a folded player no live hand can win aren't lost.
+func TestDeadMoney(t *testing.T) {
+	texas := testGameWithStacks(t, 100, 10, 20)
+	total := 0
+	for _, player := range texas.Players {
+		total += player.Assets + player.Bet
+	}
+
+	if err := texas.AdvanceState("Player 0", Raise, 50); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := texas.Disconnect("Player 0"); err != nil {
+		t.Fatal(err)
+	}
+
+	moves := []testPlayerAction{
+		{"Player 1", AllIn, 0},
+		{"Player 2", AllIn, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	if !texas.IsHandOver() {
+		t.Fatalf("expected the hand to be over")
+	}
+
+	after := 0
+	for _, player := range texas.Players {
+		after += player.Assets
+	}
+
+	if after != total {
+		t.Errorf("expected the table to keep %d chips, got %d", total, after)
+	}
+}
+
+// TestShortBlind tests that a player who cannot cover the blind goes all-in.
+func TestShortBlind(t *testing.T) {
+	texas := testGameWithStacks(t, 100, 100, 1)
+	if !texas.Players[2].AllIn || texas.Players[2].Bet != 1 {
+		t.Errorf("expected big blind to be all-in for 1, got %+v", texas.Players[2])
+	}
+
+	if err := texas.AdvanceState("Player 2", Call, 0); err == nil || !errors.Is(err, WrongTurnErr) {
+		t.Errorf("expected wrong turn error, got %v", err)
+	}
+}
+
+// TestRaiseSizes tests the raise sizing rules of the betting structures.
+func TestRaiseSizes(t *testing.T) {
+	tt := []struct {
+		name      string
+		structure BettingStructure
+		moves     []testPlayerAction
+		err       error
+		activeBet int
+	}{
+		{
+			name:      "no-limit minimum raise",
+			structure: NoLimit,
+			moves:     []testPlayerAction{{"Player 0", Raise, 0}},
+			activeBet: 4,
+		},
+		{
+			name:      "no-limit raise too small",
+			structure: NoLimit,
+			moves:     []testPlayerAction{{"Player 0", Raise, 3}},
+			err:       InvalidBetSizeErr,
+		},
+		{
+			name:      "no-limit reraise smaller than the last raise",
+			structure: NoLimit,
+			moves:     []testPlayerAction{{"Player 0", Raise, 10}, {"Player 1", Raise, 15}},
+			err:       InvalidBetSizeErr,
+		},
+		{
+			name:      "no-limit reraise",
+			structure: NoLimit,
+			moves:     []testPlayerAction{{"Player 0", Raise, 10}, {"Player 1", Raise, 18}},
+			activeBet: 18,
+		},
+		{
+			name:      "no-limit raise above the stack",
+			structure: NoLimit,
+			moves:     []testPlayerAction{{"Player 0", Raise, 101}},
+			err:       InvalidBetSizeErr,
+		},
+		{
+			name:      "pot-limit raise the pot",
+			structure: PotLimit,
+			moves:     []testPlayerAction{{"Player 0", Raise, 7}},
+			activeBet: 7,
+		},
+		{
+			name:      "pot-limit raise above the pot",
+			structure: PotLimit,
+			moves:     []testPlayerAction{{"Player 0", Raise, 8}},
+			err:       InvalidBetSizeErr,
+		},
+		{
+			name:      "pot-limit all-in above the pot",
+			structure: PotLimit,
+			moves:     []testPlayerAction{{"Player 0", AllIn, 0}},
+			err:       InvalidBetSizeErr,
+		},
+		{
+			name:      "fixed-limit raise",
+			structure: FixedLimit,
+			moves:     []testPlayerAction{{"Player 0", Raise, 4}},
+			activeBet: 4,
+		},
+		{
+			name:      "fixed-limit wrong size",
+			structure: FixedLimit,
+			moves:     []testPlayerAction{{"Player 0", Raise, 6}},
+			err:       InvalidBetSizeErr,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			texas := testGame()
+			texas.Config.Structure = tc.structure
+			err := handlePlayerActions(texas, tc.moves)
+			if tc.err != nil {
+				if err == nil || !errors.Is(err, tc.err) {
+					t.Fatalf("expected %v, got %v", tc.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if texas.ActiveBet != tc.activeBet {
+				t.Errorf("expected active bet to be %d, got %d", tc.activeBet, texas.ActiveBet)
+			}
+		})
+	}
+}
+
+// TestSplitPot tests that tied hands split the pot and the odd chip goes left of the button.
+func TestSplitPot(t *testing.T) {
+	texas := testGameWithStacks(t, 100, 100, 100)
+	moves := []testPlayerAction{
+		{"Player 0", Fold, 0},
+		{"Player 1", Raise, 6},
+		{"Player 2", Call, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	// Add a chip from the folded player so the pot cannot be split evenly
+	texas.Players[0].TotalBet = 1
+	texas.CommunityCards = []poker.Card{
+		poker.NewCard("Ts"),
+		poker.NewCard("Jh"),
+		poker.NewCard("Qc"),
+		poker.NewCard("Kd"),
+		poker.NewCard("As"),
+	}
+	texas.Players[1].HoleCards = []poker.Card{poker.NewCard("2d"), poker.NewCard("2c")}
+	texas.Players[2].HoleCards = []poker.Card{poker.NewCard("3h"), poker.NewCard("4s")}
+	texas.Players[1].Assets = 0
+	texas.Players[2].Assets = 0
+
+	texas.awardPots()
+
+	if len(texas.Winners) != 2 {
+		t.Fatalf("expected 2 winners, got %v", texas.Winners)
+	}
+
+	if texas.Players[1].Assets != 7 || texas.Players[2].Assets != 6 {
+		t.Errorf("expected a 7/6 split, got %d/%d", texas.Players[1].Assets, texas.Players[2].Assets)
+	}
+
+	if texas.Winners[0].Name != "Player 1" || texas.Winners[0].Amount != 7 {
+		t.Errorf("expected player 1 to win 7, got %+v", texas.Winners[0])
+	}
+
+	if len(texas.Pots[0].Winners) != 2 {
+		t.Errorf("expected the pot to have 2 winners, got %v", texas.Pots[0].Winners)
+	}
+}
+
+// TestNextHand tests that the button and the blinds move and the chips carry over.
+func TestNextHand(t *testing.T) {
+	texas := testGameWithStacks(t, 100, 100, 100, 100)
+	if texas.Dealer != 0 || texas.SmallBlind != 1 || texas.BigBlind != 2 || texas.CurrentPlayer != 3 {
+		t.Fatalf("expected positions 0/1/2/3, got %d/%d/%d/%d", texas.Dealer, texas.SmallBlind, texas.BigBlind, texas.CurrentPlayer)
+	}
+
+	if err := texas.NextHand(); err == nil || !errors.Is(err, GameStillInProgressErr) {
+		t.Errorf("expected game still in progress error, got %v", err)
+	}
+
+	moves := []testPlayerAction{
+		{"Player 3", Fold, 0},
+		{"Player 0", Fold, 0},
+		{"Player 1", Fold, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	if !texas.IsHandOver() {
+		t.Fatalf("expected hand to be over, got not over")
+	}
+
+	if err := texas.NextHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	if texas.Dealer != 1 || texas.SmallBlind != 2 || texas.BigBlind != 3 || texas.CurrentPlayer != 0 {
+		t.Errorf("expected positions 1/2/3/0, got %d/%d/%d/%d", texas.Dealer, texas.SmallBlind, texas.BigBlind, texas.CurrentPlayer)
+	}
+
+	if texas.Players[2].Assets != 101-texas.Config.SmallBlind {
+		t.Errorf("expected player 2 to carry the won blind over, got %d", texas.Players[2].Assets)
+	}
+
+	if texas.HandNumber != 2 || len(texas.Players[0].HoleCards) != 2 || len(texas.CommunityCards) != 0 {
+		t.Errorf("expected a freshly dealt second hand, got %+v", texas)
+	}
+}
+
+// TestDisconnectAllIn tests that a player who leaves while all-in keeps their hand.
+func TestDisconnectAllIn(t *testing.T) {
+	texas := testGameWithStacks(t, 100, 100, 100, 100)
+	if err := texas.AdvanceState("Player 3", AllIn, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := texas.Disconnect("Player 3"); err != nil {
+		t.Fatal(err)
+	}
+
+	player := texas.Players[3]
+	if !player.Left || !player.Active || !player.AllIn || player.Action != AllIn {
+		t.Fatalf("expected player 3 to stay all-in after leaving, got %+v", player)
+	}
+
+	moves := []testPlayerAction{
+		{"Player 0", Fold, 0},
+		{"Player 1", Fold, 0},
+		{"Player 2", Fold, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	if !texas.IsHandOver() || len(texas.Winners) != 1 || texas.Winners[0].Name != "Player 3" {
+		t.Errorf("expected player 3 to win the hand, got %v", texas.Winners)
+	}
+}
+
+// TestNextHandRemovesPlayers tests that players who left or went broke lose their seat.
+func TestNextHandRemovesPlayers(t *testing.T) {
+	texas := testGameWithStacks(t, 100, 100, 100, 100)
+	if err := texas.Disconnect("Player 1"); err != nil {
+		t.Fatal(err)
+	}
+
+	moves := []testPlayerAction{
+		{"Player 3", Fold, 0},
+		{"Player 0", Fold, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	if !texas.IsHandOver() || texas.Winners[0].Name != "Player 2" {
+		t.Fatalf("expected player 2 to win the hand, got %v", texas.Winners)
+	}
+
+	if err := texas.NextHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(texas.Players) != 3 || texas.Players[0].Name != "Player 0" || texas.Players[1].Name != "Player 2" {
+		t.Fatalf("expected player 1 to be removed, got %+v", texas.Players)
+	}
+
+	if texas.Players[texas.Dealer].Name != "Player 2" {
+		t.Errorf("expected the button to skip the player who left, got %s", texas.Players[texas.Dealer].Name)
+	}
+
+	texas.HandOver = true
+	texas.Players[0].Left = true
+	if err := texas.NextHand(); err == nil || !errors.Is(err, NotEnoughPlayersErr) {
+		t.Errorf("expected not enough players error, got %v", err)
+	}
+
+	if !texas.IsGameOver() {
+		t.Errorf("expected game to be over, got not over")
+	}
+}
+
+// TestTableConfig tests the antes, the buy-in limits and the player cap.
+func TestTableConfig(t *testing.T) {
+	config := DefaultConfig()
+	config.Ante = 1
+	config.SmallBlind = 5
+	config.BigBlind = 10
+	texas := NewTexasHoldEm(config)
+
+	if err := texas.AddPlayer("Player 0", config.MinBuyIn-1); err == nil || !errors.Is(err, InvalidAssetErr) {
+		t.Errorf("expected invalid asset error, got %v", err)
+	}
+
+	if err := texas.AddPlayer("Player 0", config.MaxBuyIn+1); err == nil || !errors.Is(err, InvalidAssetErr) {
+		t.Errorf("expected invalid asset error, got %v", err)
+	}
+
+	for i := 0; i < config.MaxPlayers; i++ {
+		if err := texas.AddPlayer(fmt.Sprintf("Player %d", i), 100); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := texas.AddPlayer("Player 9", 100); err == nil || !errors.Is(err, TableFullErr) {
+		t.Errorf("expected table full error, got %v", err)
+	}
+
+	if err := texas.StartGame(); err != nil {
+		t.Fatal(err)
+	}
+
+	if texas.PotTotal() != 18 {
+		t.Errorf("expected the antes and the blinds to make a pot of 18, got %d", texas.PotTotal())
+	}
+
+	if texas.ActiveBet != 10 || texas.Players[texas.SmallBlind].Bet != 5 {
+		t.Errorf("expected the antes not to count towards the bets, got %d and %d", texas.ActiveBet, texas.Players[texas.SmallBlind].Bet)
+	}
+}
+
+// TestHistory tests that the hand history records the blinds, the actions and the showdown.
+func TestHistory(t *testing.T) {
+	texas := testGame()
+	moves := []testPlayerAction{
+		{"Player 0", Raise, 6},
+		{"Player 1", Fold, 0},
+		{"Player 2", Call, 0},
+		{"Player 2", Call, 0},
+		{"Player 0", Call, 0},
+		{"Player 2", Call, 0},
+		{"Player 0", Call, 0},
+		{"Player 2", Call, 0},
+		{"Player 0", Call, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	if !texas.IsHandOver() {
+		t.Fatalf("expected hand to be over, got not over")
+	}
+
+	history := texas.History()
+	if len(history.Seats) != 3 || history.Seats[1].Stack != 100 {
+		t.Errorf("expected 3 seats with the stacks before the blinds, got %+v", history.Seats)
+	}
+
+	if len(history.Actions) != len(moves)+2 {
+		t.Fatalf("expected %d actions, got %d", len(moves)+2, len(history.Actions))
+	}
+
+	if history.Actions[0].Action != PostSmallBlind || history.Actions[1].Action != PostBigBlind {
+		t.Errorf("expected the blinds to be posted first, got %+v", history.Actions[:2])
+	}
+
+	raise := history.Actions[2]
+	if raise.Action != Raise || raise.Amount != 6 || raise.Bet != 6 {
+		t.Errorf("expected a raise to 6, got %+v", raise)
+	}
+
+	call := history.Actions[4]
+	if call.Action != Call || call.Amount != 4 || call.Round != PreFlop {
+		t.Errorf("expected a call of 4 preflop, got %+v", call)
+	}
+
+	if len(history.Board) != 5 || len(history.Pots) != 1 || history.Pots[0].Amount != 13 {
+		t.Errorf("expected a full board and a pot of 13, got %v and %+v", history.Board, history.Pots)
+	}
+
+	if !history.Seats[0].Shown || history.Seats[1].Shown || !history.Seats[2].Shown {
+		t.Errorf("expected only the players at the showdown to show their cards, got %+v", history.Seats)
+	}
+}
+
+// TestTimeout tests acting for the players who ran out of time.
+func TestTimeout(t *testing.T) {
+	texas := testGame()
+	action, err := texas.Timeout()
+	if err != nil || action != Fold || texas.Players[0].Active {
+		t.Fatalf("expected player 0 to fold facing the big blind, got %s and %v", action, err)
+	}
+
+	if err := texas.AdvanceState("Player 1", Call, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// The big blind owes nothing and checks their option
+	if action, err = texas.Timeout(); err != nil || action != Check {
+		t.Fatalf("expected the big blind to check, got %s and %v", action, err)
+	}
+
+	if texas.Round != Flop || texas.PotTotal() != 4 {
+		t.Fatalf("expected the flop with a pot of 4, got %s and %d", texas.Round, texas.PotTotal())
+	}
+
+	if action, err = texas.Timeout(); err != nil || action != Check {
+		t.Fatalf("expected a check on the flop, got %s and %v", action, err)
+	}
+
+	texas.HandOver = true
+	if _, err = texas.Timeout(); err == nil || !errors.Is(err, HandOverErr) {
+		t.Errorf("expected hand over error, got %v", err)
+	}
+}
+
+// TestSanitizeShowdown tests that only the cards shown at the showdown are revealed.
+func TestSanitizeShowdown(t *testing.T) {
+	texas := testGame()
+	moves := []testPlayerAction{
+		{"Player 0", Fold, 0},
+		{"Player 1", Call, 0},
+		{"Player 2", Call, 0},
+		{"Player 1", AllIn, 0},
+		{"Player 2", Call, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	if !texas.IsHandOver() {
+		t.Fatalf("expected hand to be over, got not over")
+	}
+
+	spectator := texas.SanitizeState("")
+	if len(spectator.Players[0].HoleCards) != 0 {
+		t.Errorf("expected the folded cards to stay hidden, got %v", spectator.Players[0].HoleCards)
+	}
+
+	for _, i := range []int{1, 2} {
+		if len(spectator.Players[i].HoleCards) != 2 {
+			t.Errorf("expected player %d to show their cards, got %v", i, spectator.Players[i].HoleCards)
+		}
+	}
+
+	if player := texas.SanitizeState("Player 0"); len(player.Players[0].HoleCards) != 2 {
+		t.Errorf("expected player 0 to see their own cards, got %v", player.Players[0].HoleCards)
+	}
+}
+
+// TestDisconnectBeforeStart tests that leaving before the game starts frees the seat.
+func TestDisconnectBeforeStart(t *testing.T) {
+	texas := NewTexasHoldEm(DefaultConfig())
+	for i := 0; i < 2; i++ {
+		if err := texas.AddPlayer(fmt.Sprintf("Player %d", i), 100); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := texas.Disconnect("Player 0"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := texas.FindPlayer("Player 0"); ok || len(texas.Players) != 1 {
+		t.Fatalf("expected the seat to be freed, got %+v", texas.Players)
+	}
+
+	for i := 2; i < 4; i++ {
+		if err := texas.AddPlayer(fmt.Sprintf("Player %d", i), 100); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := texas.StartGame(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestChooseSeat tests that the players are ordered by the seats they pick.
+func TestChooseSeat(t *testing.T) {
+	config := DefaultConfig()
+	config.MaxPlayers = 6
+	texas := NewTexasHoldEm(config)
+	for i := 0; i < 3; i++ {
+		if err := texas.AddPlayer(fmt.Sprintf("Player %d", i), 100); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := texas.ChooseSeat("Player 0", 5); err != nil {
+		t.Fatal(err)
+	}
+
+	if texas.Players[0].Name != "Player 1" || texas.Players[2].Name != "Player 0" || texas.Players[2].Seat != 5 {
+		t.Fatalf("expected player 0 to move to the last seat, got %+v", texas.Players)
+	}
+
+	if err := texas.ChooseSeat("Player 1", 2); err == nil || !errors.Is(err, SeatTakenErr) {
+		t.Errorf("expected seat taken error, got %v", err)
+	}
+
+	if err := texas.ChooseSeat("Player 1", texas.Config.MaxPlayers); err == nil || !errors.Is(err, InvalidSeatErr) {
+		t.Errorf("expected invalid seat error, got %v", err)
+	}
+
+	if err := texas.AddPlayer("Player 3", 100); err != nil {
+		t.Fatal(err)
+	}
+
+	if texas.Players[0].Name != "Player 3" || texas.Players[0].Seat != 0 {
+		t.Errorf("expected player 3 to take the free seat 0, got %+v", texas.Players[0])
+	}
+
+	if err := texas.StartGame(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := texas.ChooseSeat("Player 3", 4); err == nil || !errors.Is(err, GameStillInProgressErr) {
+		t.Errorf("expected game still in progress error, got %v", err)
+	}
+}
+
+// TestSitOut tests that the players sitting out are skipped when dealing and come back after the hands.
+func TestSitOut(t *testing.T) {
+	texas := testGameWithStacks(t, 100, 100, 100, 100)
+	if err := texas.SitOut("Player 3", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	moves := []testPlayerAction{
+		{"Player 3", Fold, 0},
+		{"Player 0", Fold, 0},
+		{"Player 1", Fold, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := texas.NextHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	if texas.Players[3].Active || len(texas.Players[3].HoleCards) != 0 {
+		t.Fatalf("expected player 3 to sit out the hand, got %+v", texas.Players[3])
+	}
+
+	if texas.Dealer != 1 || texas.SmallBlind != 2 || texas.BigBlind != 0 || texas.CurrentPlayer != 1 {
+		t.Fatalf("expected positions 1/2/0/1, got %d/%d/%d/%d", texas.Dealer, texas.SmallBlind, texas.BigBlind, texas.CurrentPlayer)
+	}
+
+	moves = []testPlayerAction{
+		{"Player 1", Fold, 0},
+		{"Player 2", Fold, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := texas.NextHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	if texas.Players[3].SittingOut || !texas.Players[3].Active || texas.SmallBlind != 3 {
+		t.Errorf("expected player 3 to be back in the small blind, got %+v", texas.Players[3])
+	}
+}
+
+// TestSitOutWaiting tests that the game waits when too many players are sitting out.
+func TestSitOutWaiting(t *testing.T) {
+	texas := testGameWithStacks(t, 100, 100, 100)
+	if err := texas.SitOut("Player 0", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	moves := []testPlayerAction{
+		{"Player 0", Fold, 0},
+		{"Player 1", Fold, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := texas.NextHand(); err == nil || !errors.Is(err, NotEnoughPlayersErr) {
+		t.Fatalf("expected not enough players error, got %v", err)
+	}
+
+	if !texas.IsWaiting() || texas.IsGameOver() {
+		t.Fatalf("expected the game to wait, got waiting %v and game over %v", texas.IsWaiting(), texas.IsGameOver())
+	}
+
+	if err := texas.SitIn("Player 0"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := texas.NextHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	if texas.IsWaiting() || texas.HandNumber != 2 || !texas.Players[0].Active {
+		t.Errorf("expected the second hand to be dealt to everyone, got %+v", texas.Players)
+	}
+}
+
+// TestTopUp tests adding chips to a stack between hands.
+func TestTopUp(t *testing.T) {
+	texas := testGameWithStacks(t, 100, 100, 100)
+	if err := texas.TopUp("Player 0", 10); err == nil || !errors.Is(err, GameStillInProgressErr) {
+		t.Errorf("expected game still in progress error, got %v", err)
+	}
+
+	moves := []testPlayerAction{
+		{"Player 0", Fold, 0},
+		{"Player 1", Fold, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := texas.TopUp("Player 0", texas.Config.MaxBuyIn); err == nil || !errors.Is(err, InvalidAssetErr) {
+		t.Errorf("expected invalid asset error, got %v", err)
+	}
+
+	if err := texas.TopUp("Player 0", 50); err != nil {
+		t.Fatal(err)
+	}
+
+	if texas.Players[0].Assets != 150 {
+		t.Errorf("expected a stack of 150, got %d", texas.Players[0].Assets)
+	}
+}
+
+// TestShortStackSitsOut tests that the players who cannot post the blinds sit out until they top up.
+func TestShortStackSitsOut(t *testing.T) {
+	texas := testGameWithStacks(t, 100, 100, 100, 100)
+	moves := []testPlayerAction{
+		{"Player 3", Fold, 0},
+		{"Player 0", Fold, 0},
+		{"Player 1", Fold, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	texas.Players[3].Assets = texas.Config.BigBlind - 1
+	if err := texas.NextHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !texas.Players[3].SittingOut || texas.Players[3].Active {
+		t.Fatalf("expected player 3 to sit out, got %+v", texas.Players[3])
+	}
+
+	if err := texas.SitIn("Player 3"); err == nil || !errors.Is(err, NotEnoughMoneyErr) {
+		t.Errorf("expected not enough money error, got %v", err)
+	}
+
+	if err := texas.TopUp("Player 3", 100); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := texas.SitIn("Player 3"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testSitAndGo creates a started sit-and-go with the players.
+func testSitAndGo(t *testing.T, players int) *TexasHoldEm {
+	t.Helper()
+
+	config := DefaultConfig()
+	config.SmallBlind = BlindSchedule[0].SmallBlind
+	config.BigBlind = BlindSchedule[0].BigBlind
+	config.StartingStack = 1500
+	config.MinBuyIn = 1500
+	config.MaxBuyIn = 1500
+	config.MaxPlayers = players
+	config.BuyIn = 10
+	config.LevelMinutes = 5
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	texas := NewTexasHoldEm(config)
+	for i := 0; i < players; i++ {
+		if err := texas.StartGame(); err == nil || !errors.Is(err, NotEnoughPlayersErr) {
+			t.Fatalf("expected not enough players error before the table is full, got %v", err)
+		}
+
+		if err := texas.AddPlayer(fmt.Sprintf("Player %d", i), 1500); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := texas.StartGame(); err != nil {
+		t.Fatal(err)
+	}
+
+	return texas
+}
+
+// TestSitAndGo tests the blind levels, the eliminations and the payouts of a sit-and-go.
+func TestSitAndGo(t *testing.T) {
+	texas := testSitAndGo(t, 4)
+	if err := texas.TopUp("Player 0", 100); err == nil || !errors.Is(err, InvalidActionErr) {
+		t.Errorf("expected invalid action error for a rebuy, got %v", err)
+	}
+
+	if err := texas.SitOut("Player 0", 0); err == nil || !errors.Is(err, InvalidActionErr) {
+		t.Errorf("expected invalid action error for sitting out, got %v", err)
+	}
+
+	if err := texas.RaiseBlinds(); err != nil {
+		t.Fatal(err)
+	}
+
+	moves := []testPlayerAction{
+		{"Player 3", Fold, 0},
+		{"Player 0", Fold, 0},
+		{"Player 1", Fold, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	// Player 1 started the hand with the smaller stack, so they finish lower
+	texas.Players[0].Assets = 0
+	texas.Players[1].Assets = 0
+	texas.history.Seats[1].Stack = 100
+	if err := texas.NextHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(texas.Players) != 2 || len(texas.Eliminated) != 2 || texas.Eliminated[0] != "Player 1" {
+		t.Fatalf("expected players 1 and 0 to be eliminated in order, got %v", texas.Eliminated)
+	}
+
+	if texas.Config.BigBlind != BlindSchedule[1].BigBlind {
+		t.Errorf("expected the big blind of the second level, got %d", texas.Config.BigBlind)
+	}
+
+	if texas.Payouts() != nil {
+		t.Errorf("expected no payouts before the tournament is over, got %v", texas.Payouts())
+	}
+
+	texas.HandOver = true
+	texas.Players[1].Assets = 0
+	if err := texas.NextHand(); err == nil || !errors.Is(err, NotEnoughPlayersErr) {
+		t.Fatalf("expected not enough players error, got %v", err)
+	}
+
+	if !texas.IsGameOver() {
+		t.Fatalf("expected the tournament to be over")
+	}
+
+	standings := texas.Standings()
+	if fmt.Sprint(standings) != "[Player 2 Player 3 Player 0 Player 1]" {
+		t.Errorf("expected the finishing order 2/3/0/1, got %v", standings)
+	}
+
+	payouts := texas.Payouts()
+	if len(payouts) != 2 || payouts[0].Name != "Player 2" || payouts[0].Amount != 26 || payouts[1].Amount != 14 {
+		t.Errorf("expected a 26/14 split of the 40 chip prize pool, got %+v", payouts)
+	}
+}
+
+// TestTransfer tests moving players between the tables of a multi-table tournament.
+func TestTransfer(t *testing.T) {
+	config := DefaultConfig()
+	config.SmallBlind = BlindSchedule[0].SmallBlind
+	config.BigBlind = BlindSchedule[0].BigBlind
+	config.StartingStack = 1500
+	config.MinBuyIn = 1500
+	config.MaxBuyIn = 1500
+	config.MaxPlayers = 6
+	config.BuyIn = 10
+	config.LevelMinutes = 5
+
+	texas := NewTournamentTable(config)
+	for i := 0; i < 3; i++ {
+		if err := texas.AddPlayer(fmt.Sprintf("Player %d", i), 1500); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The director starts the table without filling it
+	if err := texas.StartGame(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := texas.TransferOut("Player 0"); err == nil || !errors.Is(err, GameStillInProgressErr) {
+		t.Errorf("expected game still in progress error for a move mid-hand, got %v", err)
+	}
+
+	if err := texas.TransferIn("Player 3", 2000); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := texas.TransferIn("Player 3", 2000); err == nil || !errors.Is(err, InvalidActionErr) {
+		t.Errorf("expected invalid action error for a player seated twice, got %v", err)
+	}
+
+	if len(texas.Remaining()) != 4 {
+		t.Errorf("expected 4 remaining players, got %v", texas.Remaining())
+	}
+
+	texas.HandOver = true
+	bigBlind := texas.NextBigBlind()
+	stack, err := texas.TransferOut(bigBlind)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if texas.playerIndex(bigBlind) != -1 || len(texas.Players) != 3 {
+		t.Errorf("expected %s to leave the table", bigBlind)
+	}
+
+	if bigBlind == "Player 3" && stack != 2000 || bigBlind != "Player 3" && stack == 0 {
+		t.Errorf("expected the stack of %s to move with them, got %d", bigBlind, stack)
+	}
+
+	texas.SetLevel(len(BlindSchedule) + 5)
+	if texas.Level != len(BlindSchedule)-1 {
+		t.Errorf("expected the level to stop at the last one, got %d", texas.Level)
+	}
+
+	texas.Players[0].Assets = 0
+	eliminated := texas.EliminatePlayers()
+	if len(eliminated) != 1 || eliminated[0] != texas.Players[0].Name {
+		t.Errorf("expected %s to be eliminated, got %v", texas.Players[0].Name, eliminated)
+	}
+
+	if texas.Payouts() != nil {
+		t.Errorf("expected the director to pay the prizes, got %v", texas.Payouts())
+	}
+}
+
+// TestFairShuffle tests the commitment to the server seed and the verification of the deal.
+func TestFairShuffle(t *testing.T) {
+	texas := NewTexasHoldEm(DefaultConfig())
+	for _, name := range []string{"Player 0", "Player 1", "Player 2"} {
+		if err := texas.AddPlayer(name, 100); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := texas.SetSeed("Player 1", strings.Repeat("x", 65)); err == nil || !errors.Is(err, InvalidSeedErr) {
+		t.Errorf("expected invalid seed error for a long seed, got %v", err)
+	}
+
+	if err := texas.SetSeed("Player 1", "lucky"); err != nil {
+		t.Fatal(err)
+	}
+
+	commitment := texas.NextCommitment
+	if err := texas.StartGame(); err != nil {
+		t.Fatal(err)
+	}
+
+	if texas.Seeds.Commitment != commitment || texas.NextCommitment == commitment {
+		t.Errorf("expected the hand to use the published commitment and a new one for the next hand")
+	}
+
+	if len(texas.Seeds.PlayerSeeds) != 3 || texas.Seeds.PlayerSeeds[1].Seed != "lucky" {
+		t.Errorf("expected the seeds of the three players, got %v", texas.Seeds.PlayerSeeds)
+	}
+
+	if texas.SanitizeState("Player 0").Seeds.ServerSeed != "" {
+		t.Errorf("expected the server seed to be hidden during the hand")
+	}
+
+	holeCards := map[string][]poker.Card{}
+	for _, player := range texas.Players {
+		holeCards[player.Name] = player.HoleCards
+	}
+
+	moves := []testPlayerAction{
+		{"Player 0", Call, 0},
+		{"Player 1", Call, 0},
+		{"Player 2", Call, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := VerifyDeal(texas.Seeds, holeCards, texas.CommunityCards); err != nil {
+		t.Errorf("expected the deal to match the seeds, got %v", err)
+	}
+
+	texas.HandOver = true
+	history := texas.History()
+	if texas.SanitizeState("").Seeds.ServerSeed != "" || Commit(history.Seeds.ServerSeed) != commitment {
+		t.Errorf("expected the server seed to stay hidden once the hand is over")
+	}
+
+	deck, err := ShuffleDeck(history.Seeds.ServerSeed, history.Seeds.PlayerSeeds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unique := map[poker.Card]bool{}
+	for _, card := range deck {
+		unique[card] = true
+	}
+
+	if len(deck) != 52 || len(unique) != 52 {
+		t.Errorf("expected a full deck, got %d cards", len(unique))
+	}
+
+	// Changing any of the seeds changes the deck
+	changed := append([]PlayerSeed{}, history.Seeds.PlayerSeeds...)
+	changed[1].Seed = "unlucky"
+	if other, _ := ShuffleDeck(history.Seeds.ServerSeed, changed); fmt.Sprint(other) == fmt.Sprint(deck) {
+		t.Errorf("expected a different deck with a different player seed")
+	}
+
+	tampered := history.Seeds
+	tampered.ServerSeed = strings.Repeat("ab", 32)
+	if _, err := VerifyDeal(tampered, holeCards, texas.CommunityCards); err == nil || !errors.Is(err, CommitmentMismatchErr) {
+		t.Errorf("expected commitment mismatch error, got %v", err)
+	}
+
+	holeCards["Player 2"] = []poker.Card{holeCards["Player 2"][1], holeCards["Player 2"][0]}
+	if _, err := VerifyDeal(history.Seeds, holeCards, texas.CommunityCards); err == nil || !errors.Is(err, DealMismatchErr) {
+		t.Errorf("expected deal mismatch error, got %v", err)
+	}
+}
+
+// testGameWithDeck creates a test game dealing from the deck source.
+func testGameWithDeck(t *testing.T, source DeckSource) *TexasHoldEm {
+	texas := NewTexasHoldEm(DefaultConfig())
+	texas.SetDeckSource(source)
+	for _, name := range []string{"Player 0", "Player 1", "Player 2"} {
+		if err := texas.AddPlayer(name, 100); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := texas.StartGame(); err != nil {
+		t.Fatal(err)
+	}
+
+	return texas
+}
+
+// TestStackedDeck tests dealing the exact cards of a scenario.
+func TestStackedDeck(t *testing.T) {
+	if _, err := NewStackedDeck("As Ad Xx"); err == nil || !errors.Is(err, InvalidCardErr) {
+		t.Errorf("expected invalid card error for an unknown card, got %v", err)
+	}
+
+	if _, err := NewStackedDeck("As Ad As"); err == nil || !errors.Is(err, InvalidCardErr) {
+		t.Errorf("expected invalid card error for a repeated card, got %v", err)
+	}
+
+	deck, err := NewStackedDeck("As Ad Kc Kd 7h 2c Ah Ks 9d 4c 3s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	texas := testGameWithDeck(t, deck)
+	if fmt.Sprint(texas.Players[1].HoleCards) != fmt.Sprint([]poker.Card{poker.NewCard("Kc"), poker.NewCard("Kd")}) {
+		t.Errorf("expected the second player to be dealt the kings, got %v", texas.Players[1].HoleCards)
+	}
+
+	moves := []testPlayerAction{}
+	for i := 0; i < 4; i++ {
+		for _, name := range []string{"Player 0", "Player 1", "Player 2"} {
+			moves = append(moves, testPlayerAction{player: name, action: Call})
+		}
+	}
+
+	// After the flop the player left of the button acts first
+	moves = append(moves[:3], append(moves[4:], moves[3])...)
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(texas.CommunityCards) != fmt.Sprint(deck.hands[0][6:]) {
+		t.Errorf("expected the stacked board, got %v", texas.CommunityCards)
+	}
+
+	if len(texas.Winners) != 1 || texas.Winners[0].Name != "Player 0" || texas.Winners[0].Rank != "Three of a Kind" {
+		t.Errorf("expected the aces to win with three of a kind, got %+v", texas.Winners)
+	}
+
+	if err := texas.NextHand(); err == nil || !errors.Is(err, DrawErr) {
+		t.Errorf("expected draw error once the stacked hands run out, got %v", err)
+	}
+}
+
+// TestReplayDeck tests dealing the same hands from a seed and replaying a recorded hand.
+func TestReplayDeck(t *testing.T) {
+	first := testGameWithDeck(t, NewSeededDeck(42))
+	second := testGameWithDeck(t, NewSeededDeck(42))
+	for i := range first.Players {
+		if fmt.Sprint(first.Players[i].HoleCards) != fmt.Sprint(second.Players[i].HoleCards) {
+			t.Errorf("expected the same seed to deal the same hole cards")
+		}
+	}
+
+	moves := []testPlayerAction{
+		{"Player 0", Raise, 6},
+		{"Player 1", Call, 0},
+		{"Player 2", Fold, 0},
+		{"Player 1", Call, 0},
+		{"Player 0", Call, 0},
+		{"Player 1", Call, 0},
+		{"Player 0", Call, 0},
+		{"Player 1", Call, 0},
+		{"Player 0", Call, 0},
+	}
+
+	if err := handlePlayerActions(first, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	recorded := first.History()
+	replay := testGameWithDeck(t, ReplayDeck(recorded))
+	if err := handlePlayerActions(replay, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed := replay.History()
+	if fmt.Sprint(replayed.Seats, replayed.Board, replayed.Winners) != fmt.Sprint(recorded.Seats, recorded.Board, recorded.Winners) {
+		t.Errorf("expected the replay to deal and end the same, got %+v and %+v", replayed, recorded)
+	}
+}
+
+// TestLegalActions tests that only the player to act sees their legal actions.
+func TestLegalActions(t *testing.T) {
+	texas := testGame()
+	legal := texas.SanitizeState("Player 0").Legal
+	if legal == nil || fmt.Sprint(legal.Actions) != fmt.Sprint([]PokerAction{Fold, Call, Raise, AllIn}) {
+		t.Fatalf("expected the first player to fold, call or raise preflop, got %+v", legal)
+	}
+
+	if legal.Call != 2 || legal.MinRaise != 4 || legal.MaxRaise != 100 {
+		t.Errorf("expected a call of 2 and raises between 4 and 100, got %+v", legal)
+	}
+
+	if texas.SanitizeState("Player 1").Legal != nil || texas.SanitizeState("").Legal != nil {
+		t.Errorf("expected the other players and the spectators not to see the legal actions")
+	}
+
+	moves := []testPlayerAction{
+		{"Player 0", Call, 0},
+		{"Player 1", Call, 0},
+		{"Player 2", Call, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	legal = texas.SanitizeState("Player 1").Legal
+	if legal == nil || fmt.Sprint(legal.Actions) != fmt.Sprint([]PokerAction{Fold, Check, Bet, AllIn}) {
+		t.Fatalf("expected the first player to act after the flop to check or bet, got %+v", legal)
+	}
+
+	// Capped fixed-limit betting leaves only calling and folding
+	texas = testGame()
+	texas.Config.Structure = FixedLimit
+	moves = []testPlayerAction{
+		{"Player 0", Raise, 0},
+		{"Player 1", Raise, 0},
+		{"Player 2", Raise, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	legal = texas.SanitizeState("Player 0").Legal
+	if legal == nil || legal.Allows(Raise) || legal.Allows(AllIn) || legal.Call != 4 {
+		t.Errorf("expected the capped betting to allow only folding and calling 4, got %+v", legal)
+	}
+
+	if err := texas.AdvanceState("Player 0", Raise, 0); err == nil {
+		t.Errorf("expected the raise the legal actions don't allow to be rejected")
+	}
+
+	// Going all-in for a little more than the bet is a raise as well
+	texas.Players[0].Assets = texas.ActiveBet - texas.Players[0].Bet + 1
+	if legal := texas.LegalActions("Player 0"); legal.Allows(AllIn) {
+		t.Errorf("expected the capped betting not to allow an all-in raise, got %+v", legal)
+	}
+
+	if err := texas.AdvanceState("Player 0", AllIn, 0); !errors.Is(err, InvalidBetSizeErr) {
+		t.Errorf("expected the all-in raise to be rejected, got %v", err)
+	}
+}
+
+// TestBettingRounds tests that the big blind gets their option and that a bet or a raise reopens the action.
+func TestBettingRounds(t *testing.T) {
+	texas := testGame()
+	moves := []testPlayerAction{
+		{"Player 0", Call, 0},
+		{"Player 1", Call, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	legal := texas.SanitizeState("Player 2").Legal
+	if texas.Round != PreFlop || legal == nil || !legal.Allows(Check) || !legal.Allows(Raise) || legal.Allows(Call) {
+		t.Fatalf("expected the big blind to have the option to check or raise, got %s and %+v", texas.Round, legal)
+	}
+
+	if err := texas.AdvanceState("Player 2", Raise, 6); err != nil {
+		t.Fatal(err)
+	}
+
+	if texas.Round != PreFlop || texas.CurrentPlayer != 0 {
+		t.Fatalf("expected the raise to reopen the action for player 0, got %s and player %d", texas.Round, texas.CurrentPlayer)
+	}
+
+	moves = []testPlayerAction{
+		{"Player 0", Call, 0},
+		{"Player 1", Call, 0},
+		{"Player 1", Check, 0},
+		{"Player 2", Bet, 10},
+		{"Player 0", Raise, 30},
+		{"Player 1", Fold, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	if texas.Round != Flop || texas.CurrentPlayer != 2 {
+		t.Fatalf("expected the bettor to act again on the flop, got %s and player %d", texas.Round, texas.CurrentPlayer)
+	}
+
+	if err := texas.AdvanceState("Player 2", Call, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if texas.Round != Turn || texas.CurrentPlayer != 2 || texas.ActiveBet != 0 {
+		t.Fatalf("expected the turn with player 2 to act first, got %s and player %d", texas.Round, texas.CurrentPlayer)
+	}
+
+	moves = []testPlayerAction{
+		{"Player 2", Check, 0},
+		{"Player 0", Check, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	if texas.Round != River || texas.PotTotal() != 78 {
+		t.Errorf("expected the checks to end the turn with a pot of 78, got %s and %d", texas.Round, texas.PotTotal())
+	}
+}
+
+// TestHeadsUp tests that the button posts the small blind, acts first preflop and last after the flop.
+func TestHeadsUp(t *testing.T) {
+	config := DefaultConfig()
+	config.MinPlayers = 1
+	if err := config.Validate(); err == nil || !errors.Is(err, InvalidConfigErr) {
+		t.Errorf("expected invalid config error for a single player, got %v", err)
+	}
+
+	config.MinPlayers = 4
+	if err := config.Validate(); err == nil || !errors.Is(err, InvalidConfigErr) {
+		t.Errorf("expected invalid config error for more players than seats, got %v", err)
+	}
+
+	texas := NewTexasHoldEm(DefaultConfig())
+	for _, name := range []string{"Player 0", "Player 1"} {
+		if err := texas.AddPlayer(name, 100); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := texas.StartGame(); err == nil || !errors.Is(err, NotEnoughPlayersErr) {
+		t.Errorf("expected not enough players error for a three player table, got %v", err)
+	}
+
+	config.MinPlayers = 2
+	config.MaxPlayers = 2
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	texas = NewTexasHoldEm(config)
+	for _, name := range []string{"Player 0", "Player 1"} {
+		if err := texas.AddPlayer(name, 100); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := texas.StartGame(); err != nil {
+		t.Fatal(err)
+	}
+
+	if texas.Dealer != 0 || texas.SmallBlind != 0 || texas.BigBlind != 1 || texas.CurrentPlayer != 0 {
+		t.Fatalf("expected the button to post the small blind and act first, got %d/%d/%d/%d", texas.Dealer, texas.SmallBlind, texas.BigBlind, texas.CurrentPlayer)
+	}
+
+	moves := []testPlayerAction{
+		{"Player 0", Call, 0},
+		{"Player 1", Check, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	if texas.Round != Flop || texas.CurrentPlayer != 1 {
+		t.Fatalf("expected the big blind to act first on the flop, got %s and player %d", texas.Round, texas.CurrentPlayer)
+	}
+
+	moves = []testPlayerAction{
+		{"Player 1", Check, 0},
+		{"Player 0", Bet, 2},
+		{"Player 1", Fold, 0},
+	}
+
+	if err := handlePlayerActions(texas, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	if !texas.IsHandOver() || texas.Players[0].Assets != 102 {
+		t.Fatalf("expected the button to win the pot of 4, got %+v", texas.Players)
+	}
+
+	if err := texas.NextHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	if texas.Dealer != 1 || texas.SmallBlind != 1 || texas.BigBlind != 0 || texas.CurrentPlayer != 1 {
+		t.Errorf("expected the button to move and post the small blind, got %d/%d/%d/%d", texas.Dealer, texas.SmallBlind, texas.BigBlind, texas.CurrentPlayer)
 	}
 }
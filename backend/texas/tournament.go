@@ -0,0 +1,278 @@
+package texas
+
+import (
+	"fmt"
+	"sort"
+)
+
+// BlindLevel is a level of the tournament blind schedule.
+type BlindLevel struct {
+	SmallBlind int
+	BigBlind   int
+	Ante       int
+}
+
+// BlindSchedule are the blind levels of a sit-and-go, the last level lasts until the end.
+var BlindSchedule = []BlindLevel{
+	{10, 20, 0},
+	{15, 30, 0},
+	{25, 50, 0},
+	{50, 100, 0},
+	{75, 150, 0},
+	{100, 200, 25},
+	{150, 300, 25},
+	{200, 400, 50},
+	{300, 600, 75},
+	{400, 800, 100},
+	{600, 1200, 150},
+	{800, 1600, 200},
+	{1000, 2000, 300},
+}
+
+// Payout is the prize won for a finishing place.
+type Payout struct {
+	Name   string
+	Place  int
+	Amount int
+}
+
+// PayoutTable returns the percentages of the prize pool paid to the finishing places.
+func PayoutTable(entrants int) []int {
+	switch {
+	case entrants <= 3:
+		return []int{100}
+	case entrants <= 6:
+		return []int{65, 35}
+	case entrants <= 10:
+		return []int{50, 30, 20}
+	case entrants <= 20:
+		return []int{40, 25, 15, 12, 8}
+	default:
+		return []int{30, 20, 14, 10, 8, 6, 5, 4, 3}
+	}
+}
+
+// RaiseBlinds moves the tournament to the next blind level, the blinds go up with the next hand.
+func (t *TexasHoldEm) RaiseBlinds() error {
+	if !t.Config.IsTournament() {
+		return fmt.Errorf("%w: the blinds only go up in tournaments", InvalidActionErr)
+	}
+
+	if t.Level < len(BlindSchedule)-1 {
+		t.Level++
+	}
+
+	return nil
+}
+
+// Standings returns the players in their finishing order, the ones still
+// playing are ordered by their stacks and come before the eliminated ones.
+func (t *TexasHoldEm) Standings() []string {
+	remaining := []Player{}
+	for _, player := range t.Players {
+		if !player.Left && !t.isEliminated(player.Name) {
+			remaining = append(remaining, player)
+		}
+	}
+
+	sort.SliceStable(remaining, func(i, j int) bool {
+		return remaining[i].Assets > remaining[j].Assets
+	})
+
+	standings := make([]string, 0, len(remaining)+len(t.Eliminated))
+	for _, player := range remaining {
+		standings = append(standings, player.Name)
+	}
+
+	for i := len(t.Eliminated) - 1; i >= 0; i-- {
+		standings = append(standings, t.Eliminated[i])
+	}
+
+	return standings
+}
+
+// Payouts splits the prize pool between the finishing places once the sit-and-go is over.
+func (t *TexasHoldEm) Payouts() []Payout {
+	if !t.Config.IsTournament() || !t.GameOver || t.managed {
+		return nil
+	}
+
+	return SplitPrizePool(t.Config.BuyIn*t.Entrants, t.Entrants, t.Standings())
+}
+
+// SplitPrizePool splits the prize pool between the players in their finishing order
+// using the payout table, the chips left over from rounding go to the winner.
+func SplitPrizePool(pool int, entrants int, standings []string) []Payout {
+	payouts := []Payout{}
+	paid := 0
+	for i, percentage := range PayoutTable(entrants) {
+		if i >= len(standings) {
+			break
+		}
+
+		amount := pool * percentage / 100
+		payouts = append(payouts, Payout{Name: standings[i], Place: i + 1, Amount: amount})
+		paid += amount
+	}
+
+	if len(payouts) > 0 {
+		payouts[0].Amount += pool - paid
+	}
+
+	return payouts
+}
+
+// eliminate records the players who busted or left the tournament in the last hand,
+// the ones who started the hand with a bigger stack finish higher.
+func (t *TexasHoldEm) eliminate() []string {
+	busted := []Player{}
+	for _, player := range t.Players {
+		if (player.Left || player.Assets == 0) && !t.isEliminated(player.Name) {
+			busted = append(busted, player)
+		}
+	}
+
+	startingStack := func(name string) int {
+		for _, seat := range t.history.Seats {
+			if seat.Name == name {
+				return seat.Stack
+			}
+		}
+
+		return 0
+	}
+
+	sort.SliceStable(busted, func(i, j int) bool {
+		return startingStack(busted[i].Name) < startingStack(busted[j].Name)
+	})
+
+	names := make([]string, len(busted))
+	for i, player := range busted {
+		names[i] = player.Name
+	}
+
+	t.Eliminated = append(t.Eliminated, names...)
+	return names
+}
+
+// applyLevel sets the blinds of the current tournament level.
+func (t *TexasHoldEm) applyLevel() {
+	level := BlindSchedule[t.Level]
+	t.Config.SmallBlind = level.SmallBlind
+	t.Config.BigBlind = level.BigBlind
+	t.Config.Ante = level.Ante
+}
+
+// isEliminated checks if the player is out of the tournament.
+func (t *TexasHoldEm) isEliminated(username string) bool {
+	for _, name := range t.Eliminated {
+		if name == username {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RequiredPlayers is the number of players needed to deal a hand, tournaments are played down to heads-up.
+func (t *TexasHoldEm) RequiredPlayers() int {
+	if t.Config.IsTournament() || t.Config.MinPlayers < MinimumPlayers {
+		return MinimumPlayers
+	}
+
+	return t.Config.MinPlayers
+}
+
+// NewTournamentTable creates a table of a multi-table tournament, the tournament
+// director seats the players, eliminates the busted ones and pays the prizes.
+func NewTournamentTable(config TableConfig) *TexasHoldEm {
+	t := NewTexasHoldEm(config)
+	t.managed = true
+	return t
+}
+
+// SetLevel sets the tournament blind level, the blinds change with the next hand.
+func (t *TexasHoldEm) SetLevel(level int) {
+	if level >= len(BlindSchedule) {
+		level = len(BlindSchedule) - 1
+	}
+
+	t.Level = level
+}
+
+// EliminatePlayers eliminates the players who busted or left in the last hand
+// and returns them in the order they finished, the lowest place first.
+func (t *TexasHoldEm) EliminatePlayers() []string {
+	return t.eliminate()
+}
+
+// Remaining returns the players at the table who are still in the tournament.
+func (t *TexasHoldEm) Remaining() []string {
+	remaining := []string{}
+	for _, player := range t.Players {
+		if !player.Left && !t.isEliminated(player.Name) {
+			remaining = append(remaining, player.Name)
+		}
+	}
+
+	return remaining
+}
+
+// NextBigBlind returns the player who posts the big blind in the next hand.
+func (t *TexasHoldEm) NextBigBlind() string {
+	for i := 1; i <= len(t.Players); i++ {
+		player := t.Players[(t.BigBlind+i)%len(t.Players)]
+		if !player.Left && !t.isEliminated(player.Name) {
+			return player.Name
+		}
+	}
+
+	return ""
+}
+
+// TransferIn seats a player moved from another table of the tournament with their stack,
+// they are dealt in starting with the next hand.
+func (t *TexasHoldEm) TransferIn(username string, stack int) error {
+	if t.playerIndex(username) != -1 {
+		return fmt.Errorf("%w: %s is already seated", InvalidActionErr, username)
+	}
+
+	if len(t.Players) >= t.Config.MaxPlayers {
+		return TableFullErr
+	}
+
+	t.seatPlayer(Player{
+		Name:   username,
+		Assets: stack,
+	})
+
+	return nil
+}
+
+// TransferOut removes a player who is moving to another table of the tournament
+// and returns their stack, a player can't move in the middle of a hand.
+func (t *TexasHoldEm) TransferOut(username string) (int, error) {
+	index := t.playerIndex(username)
+	if index == -1 {
+		return 0, PlayerNotInGameErr
+	}
+
+	if t.gameStarted && !t.HandOver && len(t.Players[index].HoleCards) > 0 {
+		return 0, GameStillInProgressErr
+	}
+
+	stack := t.Players[index].Assets
+	t.Players = append(t.Players[:index], t.Players[index+1:]...)
+	if !t.gameStarted || len(t.Players) == 0 {
+		return stack, nil
+	}
+
+	// Keep the positions pointing at the same players, the button moves back to the previous seat
+	for _, position := range []*int{&t.Dealer, &t.SmallBlind, &t.BigBlind, &t.CurrentPlayer, &t.roundStart} {
+		if *position >= index {
+			*position = (*position - 1 + len(t.Players)) % len(t.Players)
+		}
+	}
+
+	return stack, nil
+}
@@ -0,0 +1,43 @@
+import React, { useState } from 'react';
+import { ChatMessage, GameMessage, MsgType } from './GameMessage';
+
+interface ChatProps {
+	messages: ChatMessage[];
+	conn: WebSocket | null;
+}
+
+function Chat(props: ChatProps) {
+	const [text, setText] = useState('');
+
+	const sendChat = () => {
+		if (!props.conn || text.trim() === '') return;
+
+		let mess: GameMessage = { type: MsgType.Chat, data: text }
+		props.conn.send(JSON.stringify(mess))
+		setText('');
+	}
+
+	return (
+		<div className="w-full px-4 pb-4">
+			<div className="flex flex-col bg-gray-100 dark:bg-gray-800 rounded-xl p-4">
+				<div className="flex flex-col h-40 overflow-y-auto mb-2">
+					{props.messages.map((message, index) => (
+						<p key={index} className="text-sm text-gray-900 dark:text-gray-100">
+							<span className="text-gray-500 dark:text-gray-400">{new Date(message.time).toLocaleTimeString()} </span>
+							<span className={"font-bold " + (message.spectator ? "text-gray-500" : "text-emerald-500")}>{message.display_name}: </span>
+							{message.text}
+						</p>
+					))}
+				</div>
+				<div className="flex">
+					<input className="flex-grow rounded px-2 py-1 bg-white dark:bg-gray-700 text-gray-900 dark:text-gray-100" maxLength={200} value={text}
+						onChange={(e) => setText(e.target.value)}
+						onKeyDown={(e) => { if (e.key === 'Enter') sendChat() }} />
+					<button className="px-4 py-1 ml-2 rounded text-white font-bold bg-gradient-to-br from-emerald-400 to-emerald-500" onClick={sendChat}>Send</button>
+				</div>
+			</div>
+		</div>
+	)
+}
+
+export default Chat;
@@ -5,12 +5,29 @@ enum MsgType {
 	Error = 'error',
 	Input = 'input',
 	Action = 'action',
+	Chat = 'chat',
+	Seat = 'seat',
+	SitOut = 'sitout',
+	SitIn = 'sitin',
+	Leave = 'leave',
+	TopUp = 'topup',
+	Move = 'move',
+	Seed = 'seed',
 }
 
 interface GameMessage {
 	type: MsgType;
 	data: string;
+	amount?: number;
 }
 
-export type { GameMessage };
+interface ChatMessage {
+	username: string;
+	display_name: string;
+	text: string;
+	time: string;
+	spectator: boolean;
+}
+
+export type { GameMessage, ChatMessage };
 export { MsgType };
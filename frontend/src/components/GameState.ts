@@ -10,65 +10,203 @@ enum round {
 interface Player {
 	Name: string;
 	Active: boolean;
+	AllIn: boolean;
+	Left: boolean;
 	Action: string;
 
+	Seat: number;
+	SittingOut: boolean;
+	SitOutHands: number;
+	Seed: string;
+
 	Assets: number;
 	Bet: number;
+	TotalBet: number;
 	HoleCards: string[];
 }
 
+interface Pot {
+	Amount: number;
+	Eligible: string[];
+	Winners: string[];
+}
+
+interface Winner {
+	Name: string;
+	Amount: number;
+	Rank: string;
+	Hand: string[];
+}
+
+interface TableConfig {
+	SmallBlind: number;
+	BigBlind: number;
+	Ante: number;
+	StartingStack: number;
+	MinBuyIn: number;
+	MaxBuyIn: number;
+	MinPlayers: number;
+	MaxPlayers: number;
+	Structure: string;
+	ActionTime: number;
+	TimeBank: number;
+	BuyIn: number;
+	LevelMinutes: number;
+}
+
+interface PlayerSeed {
+	name: string;
+	seed: string;
+}
+
+interface ShuffleSeeds {
+	Commitment: string;
+	ServerSeed: string;
+	PlayerSeeds: null | PlayerSeed[];
+}
+
+interface Legal {
+	actions: string[];
+	call: number;
+	min_raise?: number;
+	max_raise?: number;
+}
+
+interface Payout {
+	Name: string;
+	Place: number;
+	Amount: number;
+}
+
 interface GameState {
+	Config: TableConfig;
 	ActiveBet: number;
-	Pot: number;
+	Pots: Pot[];
 	Round: round;
 	CurrentPlayer: number;
+	Dealer: number;
+	SmallBlind: number;
+	BigBlind: number;
+	HandNumber: number;
+	Level: number;
+	Entrants: number;
+	Eliminated: null | string[];
 
 	CommunityCards: null | string[];
 	Players: Player[];
 
+	HandOver: boolean;
 	GameOver: boolean;
-	BestHand: null | string[];
-	BestRank: string;
-	GameWinner: string;
+	Winners: null | Winner[];
+
+	TimeLeft: number;
+	TimeBank: number;
+	UsingTimeBank: boolean;
+
+	Disconnected: string[];
+	Spectators: number;
+
+	NextLevel: number;
+	Payouts: null | Payout[];
+
+	Seeds: ShuffleSeeds;
+	NextCommitment: string;
+	Legal: null | Legal;
 }
 
 const DefaultGameState: GameState = {
+	Config: {
+		SmallBlind: 1,
+		BigBlind: 2,
+		Ante: 0,
+		StartingStack: 100,
+		MinBuyIn: 40,
+		MaxBuyIn: 200,
+		MinPlayers: 3,
+		MaxPlayers: 3,
+		Structure: 'no-limit',
+		ActionTime: 30,
+		TimeBank: 60,
+		BuyIn: 0,
+		LevelMinutes: 0,
+	},
 	ActiveBet: 0,
-	Pot: 0,
+	Pots: [],
 	Round: round.PreFlop,
 	CurrentPlayer: -1,
+	Dealer: 0,
+	SmallBlind: 1,
+	BigBlind: 2,
+	HandNumber: 0,
+	Level: 0,
+	Entrants: 0,
+	Eliminated: null,
 	CommunityCards: [],
 	Players: [
 		{
 			Name: 'Player 1',
 			Active: true,
+			AllIn: false,
+			Left: false,
 			Action: 'none',
+			Seat: 0,
+			SittingOut: false,
+			SitOutHands: 0,
+			Seed: '',
 			Assets: 1000,
 			Bet: 0,
+			TotalBet: 0,
 			HoleCards: [],
 		},
 		{
 			Name: 'Player 2',
 			Active: true,
+			AllIn: false,
+			Left: false,
 			Action: 'none',
+			Seat: 1,
+			SittingOut: false,
+			SitOutHands: 0,
+			Seed: '',
 			Assets: 1000,
 			Bet: 2,
+			TotalBet: 2,
 			HoleCards: [],
 		},
 		{
 			Name: 'Player 3',
 			Active: true,
+			AllIn: false,
+			Left: false,
 			Action: 'none',
+			Seat: 2,
+			SittingOut: false,
+			SitOutHands: 0,
+			Seed: '',
 			Assets: 1000,
 			Bet: 1,
+			TotalBet: 1,
 			HoleCards: [],
 		}
 	],
+	HandOver: false,
 	GameOver: false,
-	BestHand: null,
-	BestRank: '',
-	GameWinner: '',
+	Winners: null,
+	TimeLeft: 0,
+	TimeBank: 0,
+	UsingTimeBank: false,
+	Disconnected: [],
+	Spectators: 0,
+	NextLevel: 0,
+	Payouts: null,
+	Seeds: {
+		Commitment: '',
+		ServerSeed: '',
+		PlayerSeeds: null,
+	},
+	NextCommitment: '',
+	Legal: null,
 };
 
 export { round, DefaultGameState };
-export type { Player, GameState };
+export type { Player, Pot, Winner, TableConfig, PlayerSeed, ShuffleSeeds, Legal, Payout, GameState };
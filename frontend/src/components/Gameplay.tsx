@@ -1,14 +1,16 @@
 import { read } from 'fs';
 import React, { useEffect, useRef, useState } from 'react';
-import { GameMessage, MsgType } from './GameMessage';
+import { ChatMessage, GameMessage, MsgType } from './GameMessage';
 import { GameState, DefaultGameState } from './GameState';
 import Table from './Table';
+import Chat from './Chat';
 
 function Gameplay() {
 	const ws = useRef<WebSocket | null>(null);
 	const [gameName, setGameName] = useState('');
 	const [statusMessage, setStatusMessage] = useState('');
 	const [gameState, setGameState] = useState<GameState>(DefaultGameState);
+	const [chatMessages, setChatMessages] = useState<ChatMessage[]>([]);
 	const id = require('uuid-readable');
 
 	const handleMessage = (data: string) => {
@@ -51,11 +53,26 @@ function Gameplay() {
 				console.log(gameMessage.data);
 				break
 
+			case MsgType.Chat:
+				try {
+					const chatMessage = JSON.parse(gameMessage.data) as ChatMessage;
+					setChatMessages((messages) => [...messages.slice(-49), chatMessage]);
+				} catch (e) {
+					console.error('Invalid chat message received from websocket');
+				}
+				break
+
 			case MsgType.Input:
 				// TODO: Handle input
 				console.log("Received an input message from the server");
 				console.log(gameMessage.data);
 				break
+
+			case MsgType.Move:
+				// The tournament director moved the player to another table
+				localStorage.setItem('activeGame', gameMessage.data);
+				window.location.reload();
+				break
 		}
 	}
 
@@ -86,10 +103,15 @@ function Gameplay() {
 
 		ws.current.onopen = () => {
 			setStatusMessage('Connected');
+
+			// Add our own entropy to the shuffles, the server can't pick the deck alone
+			const seed = Array.from(window.crypto.getRandomValues(new Uint8Array(16)), (b) => b.toString(16).padStart(2, '0')).join('');
+			ws.current?.send(JSON.stringify({ type: MsgType.Seed, data: seed }));
 		};
 
 		ws.current.onmessage = (event) => {
-			handleMessage(event.data);
+			// The server batches queued messages into one frame, separated by newlines
+			event.data.split('\n').forEach(handleMessage);
 		};
 
 		ws.current.onerror = () => {
@@ -115,6 +137,7 @@ function Gameplay() {
 
 			<div className="flex-grow items-center justify-center bg-white dark:bg-gray-900">
 				<Table {...{ state: gameState, conn: ws.current }} />
+				<Chat {...{ messages: chatMessages, conn: ws.current }} />
 			</div>
 		</div>
 	)
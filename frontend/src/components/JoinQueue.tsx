@@ -7,8 +7,13 @@ function JoinQueue() {
 	const id = require('uuid-readable');
 
 	const handleSubmit = async () => {
+		// An invite link takes the user to a private table, the mode picks a cash game, a sit-and-go or a multi-table tournament
+		const params = new URLSearchParams(window.location.search);
+		const inviteCode = params.get('code');
+		const mode = params.get('mode');
 		let resp = await fetch(process.env.REACT_APP_API_URL + '/api/game/queue', {
-			method: 'POST', credentials: 'include', headers: { 'Content-Type': 'application/json' }
+			method: 'POST', credentials: 'include', headers: { 'Content-Type': 'application/json' },
+			body: JSON.stringify(inviteCode ? { invite_code: inviteCode } : mode ? { mode: mode } : {})
 		})
 
 		if (resp.status === 401) {
@@ -26,6 +31,10 @@ function JoinQueue() {
 			setTimeout(() => {
 				window.location.replace('/game/play');
 			}, 500);
+		} else if (data.registered) {
+			// A multi-table tournament starts once enough players have registered, ask again for the table
+			setMessage("Registered for the tournament: " + data.registered + " of " + data.entrants + " players");
+			setTimeout(handleSubmit, 5000);
 		} else {
 			setMessage("There was an error joining the queue. Please try again later.");
 		}
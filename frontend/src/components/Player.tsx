@@ -8,6 +8,7 @@ interface PlayerCardProps {
 	IsMe: boolean;
 	HasWon: boolean;
 	GameOver: boolean;
+	Disconnected: boolean;
 }
 
 function PlayerCard(props: PlayerCardProps) {
@@ -36,6 +37,9 @@ function PlayerCard(props: PlayerCardProps) {
 			case "call":
 				setActionDescription("Called");
 				break;
+			case "bet":
+				setActionDescription("Bet");
+				break;
 			case "raise":
 				setActionDescription("Raised");
 				break;
@@ -46,6 +50,10 @@ function PlayerCard(props: PlayerCardProps) {
 		} else {
 			setActionDescription("Winner!");
 		}
+
+		if (props.Disconnected) {
+			setActionDescription("Disconnected");
+		}
 	}, [props])
 
 	return (
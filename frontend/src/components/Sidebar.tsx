@@ -50,6 +50,18 @@ function Sidebar() {
 							<span className="ml-3"> Queue </span>
 						</a>
 					</li>
+					<li>
+						<a href="/game/queue?mode=sit-and-go" className="flex items-center p-2 text-gray-900 rounded-lg dark:text-white hover:bg-gray-100 dark:hover:bg-gray-700">
+							<svg aria-hidden="true" className="w-6 h-6 text-gray-500 transition duration-75 dark:text-gray-400 group-hover:text-gray-900 dark:group-hover:text-white" fill="currentColor" viewBox="0 0 20 20" xmlns="http://www.w3.org/2000/svg"><path d="M5 3h10v3a5 5 0 01-4 4.9V13h3v2H6v-2h3v-2.1A5 5 0 015 6V3z"></path></svg>
+							<span className="ml-3"> Sit &amp; Go </span>
+						</a>
+					</li>
+					<li>
+						<a href="/game/queue?mode=multi-table" className="flex items-center p-2 text-gray-900 rounded-lg dark:text-white hover:bg-gray-100 dark:hover:bg-gray-700">
+							<svg aria-hidden="true" className="w-6 h-6 text-gray-500 transition duration-75 dark:text-gray-400 group-hover:text-gray-900 dark:group-hover:text-white" fill="currentColor" viewBox="0 0 20 20" xmlns="http://www.w3.org/2000/svg"><path d="M5 3h10v3a5 5 0 01-4 4.9V13h3v2H6v-2h3v-2.1A5 5 0 015 6V3z"></path></svg>
+							<span className="ml-3"> Tournament </span>
+						</a>
+					</li>
 
 					{!isLoggedIn &&
 						<div>
@@ -4,6 +4,16 @@ import PlayerCard from './Player';
 import PlayingCard from './Card';
 import { GameMessage, MsgType } from './GameMessage';
 
+// The action buttons, only the actions the server allows are enabled. Betting
+// opens a street and takes the place of raising.
+const actionButtons = [
+	{ action: "fold", label: "Fold", style: "bg-gradient-to-br from-red-400 to-red-500 hover:bg-gradient-to-br hover:from-red-500 hover:to-red-500" },
+	{ action: "check", label: "Check", style: "bg-gray-300 dark:bg-gray-800 hover:bg-gray-900" },
+	{ action: "call", label: "Call", style: "bg-gray-300 dark:bg-gray-800 hover:bg-gray-900" },
+	{ action: "bet", label: "Bet", style: "bg-gradient-to-br from-emerald-400 to-emerald-500 hover:bg-gradient-to-br hover:from-emerald-500 hover:to-emerald-500" },
+	{ action: "raise", label: "Raise", style: "bg-gradient-to-br from-emerald-400 to-emerald-500 hover:bg-gradient-to-br hover:from-emerald-500 hover:to-emerald-500" },
+];
+
 interface TableProps {
 	state: GameState;
 	conn: WebSocket | null;
@@ -11,8 +21,25 @@ interface TableProps {
 
 function Table(props: TableProps) {
 	const [myIndex, setMyIndex] = React.useState(-1);
-	const [winnerIndex, setWinnerIndex] = React.useState(-1);
+	const [winnerIndexes, setWinnerIndexes] = React.useState<number[]>([]);
 	const [communityCards, setCommunityCards] = React.useState<string[]>([""]);
+	const [secondsLeft, setSecondsLeft] = React.useState(0);
+
+	const sendMessage = (mess: GameMessage) => {
+		if (props.conn) {
+			props.conn.send(JSON.stringify(mess))
+		}
+	}
+
+	// Count down the clock of the player to act, the server sends the time left in milliseconds
+	useEffect(() => {
+		const deadline = Date.now() + props.state.TimeLeft;
+		const update = () => setSecondsLeft(Math.max(0, Math.ceil((deadline - Date.now()) / 1000)));
+		update();
+
+		const interval = setInterval(update, 250);
+		return () => clearInterval(interval);
+	}, [props.state])
 
 	useEffect(() => {
 		for (let i = 0; i < props.state.Players.length; i++) {
@@ -32,13 +59,17 @@ function Table(props: TableProps) {
 			cards.push("");
 		}
 
-		if (props.state.GameOver) {
+		setWinnerIndexes([]);
+		if (props.state.HandOver && props.state.Winners) {
+			let winners = props.state.Winners.map((winner) => winner.Name);
+			let indexes: number[] = [];
 			for (let i = 0; i < props.state.Players.length; i++) {
-				if (props.state.Players[i].Name === props.state.GameWinner) {
-					setWinnerIndex(i);
-					break;
+				if (winners.includes(props.state.Players[i].Name)) {
+					indexes.push(i);
 				}
 			}
+
+			setWinnerIndexes(indexes);
 		}
 
 		setCommunityCards(cards);
@@ -49,8 +80,31 @@ function Table(props: TableProps) {
 			<div className="flex flex-col dark:bg-gradient-to-br bg-gray-100 dark:from-gray-800 dark:to-gray-700 rounded-xl space-y-10 p-0">
 				<div className="top-0 left-0 pl-4 pt-2">
 					<h1 className="text-xl font-bold text-gray-900 dark:text-gray-100">
-						Pot <span className="ml-2 text-red-500">{props.state.Pot}</span>
+						Pot <span className="ml-2 text-red-500">{props.state.Pots.reduce((total, pot) => total + pot.Amount, 0)}</span>
 					</h1>
+					<h2 className="text-sm text-gray-500 dark:text-gray-400">
+						{props.state.Config.Structure} {props.state.Config.SmallBlind}/{props.state.Config.BigBlind}
+						{props.state.Config.Ante > 0 ? ` ante ${props.state.Config.Ante}` : ''}
+						{props.state.Spectators > 0 ? ` - ${props.state.Spectators} watching` : ''}
+					</h2>
+					{props.state.Config.BuyIn > 0 && (
+						<h2 className="text-sm text-gray-500 dark:text-gray-400">
+							Sit-and-go level {props.state.Level + 1}
+							{props.state.NextLevel > 0 ? ` - blinds up in ${Math.ceil(props.state.NextLevel / 60000)} min` : ''}
+							{props.state.Payouts ? ' - ' + props.state.Payouts.map((payout) => `${payout.Place}. ${payout.Name} wins ${payout.Amount}`).join(', ') : ''}
+						</h2>
+					)}
+					{props.state.Seeds.Commitment && (
+						<h2 className="text-xs text-gray-500 dark:text-gray-400 truncate" title="The shuffle can be verified from the hand history">
+							Shuffle commitment {props.state.Seeds.Commitment.slice(0, 16)}
+						</h2>
+					)}
+					{props.state.TimeLeft > 0 && (
+						<h2 className={"text-sm " + (props.state.UsingTimeBank ? "text-red-500" : "text-gray-500 dark:text-gray-400")}>
+							{props.state.Players[props.state.CurrentPlayer]?.Name} has {secondsLeft}s
+							{props.state.UsingTimeBank ? ' (time bank)' : ''}
+						</h2>
+					)}
 				</div>
 
 				<div className="flex flex-col items-center space-y-4">
@@ -71,60 +125,32 @@ function Table(props: TableProps) {
 
 				<div className="flex justify-center flex-wrap">
 
-					<PlayerCard {...{
-						Value: props.state.Players[0],
-						Active: 0 === props.state.CurrentPlayer,
-						IsMe: myIndex === 0,
-						HasWon: 0 === winnerIndex,
-						GameOver: props.state.GameOver
-					}} />
-
-					<PlayerCard {...{
-						Value: props.state.Players[1],
-						Active: 1 === props.state.CurrentPlayer,
-						IsMe: myIndex === 1,
-						HasWon: 1 === winnerIndex,
-						GameOver: props.state.GameOver
-					}} />
-
-					<PlayerCard {...{
-						Value: props.state.Players[2],
-						Active: 2 === props.state.CurrentPlayer,
-						IsMe: myIndex === 2,
-						HasWon: 2 === winnerIndex,
-						GameOver: props.state.GameOver
-					}} />
+					{props.state.Players.map((player, index) => (
+						<PlayerCard key={player.Name} {...{
+							Value: player,
+							Active: index === props.state.CurrentPlayer,
+							IsMe: myIndex === index,
+							HasWon: winnerIndexes.includes(index),
+							GameOver: props.state.HandOver,
+							Disconnected: props.state.Disconnected?.includes(player.Name) ?? false
+						}} />
+					))}
 
 				</div>
 
 				{
-					myIndex !== -1 && props.state.CurrentPlayer === myIndex ? (
+					props.state.Legal ? (
 						<div className="flex justify-end mb-4 flex-grow">
 							<div className="flex flex-wrap justify-evenly">
-								<button className="px-10 py-2 m-2 rounded text-white font-bold bg-gradient-to-br from-red-400 to-red-500 hover:bg-gradient-to-br hover:from-red-500 hover:to-red-500" onClick={() => {
-									if (props.conn) {
-										let mess: GameMessage = { type: MsgType.Action, data: "fold" }
-										props.conn.send(JSON.stringify(mess))
-									}
-								}}> Fold </button>
-								<button className="px-10 py-2 m-2 rounded text-white font-bold bg-gray-300 dark:bg-gray-800 hover:bg-gray-900" onClick={() => {
-									if (props.conn) {
-										let mess: GameMessage = { type: MsgType.Action, data: "check" }
-										props.conn.send(JSON.stringify(mess))
-									}
-								}}>Check</button>
-								<button className="px-10 py-2 m-2 rounded text-white font-bold bg-gray-300 dark:bg-gray-800 hover:bg-gray-900" onClick={() => {
-									if (props.conn) {
-										let mess: GameMessage = { type: MsgType.Action, data: "call" }
-										props.conn.send(JSON.stringify(mess))
-									}
-								}}>Call</button>
-								<button className="px-10 py-2 m-2 rounded text-white font-bold bg-gradient-to-br from-emerald-400 to-emerald-500 hover:bg-gradient-to-br hover:from-emerald-500 hover:to-emerald-500" onClick={() => {
-									if (props.conn) {
-										let mess: GameMessage = { type: MsgType.Action, data: "raise" }
-										props.conn.send(JSON.stringify(mess))
-									}
-								}}>Raise</button>
+								{actionButtons.filter((button) => button.action !== (props.state.Legal?.actions.includes("bet") ? "raise" : "bet")).map((button) => {
+									const allowed = props.state.Legal?.actions.includes(button.action) ?? false;
+									const label = button.action === "call" && props.state.Legal && props.state.Legal.call > 0 ? `Call ${props.state.Legal.call}` : button.label;
+									return (
+										<button key={button.action} disabled={!allowed} className={"px-10 py-2 m-2 rounded text-white font-bold " + (allowed ? button.style : "bg-gray-400 dark:bg-gray-800 opacity-50")} onClick={() => {
+											sendMessage({ type: MsgType.Action, data: button.action })
+										}}>{label}</button>
+									);
+								})}
 							</div>
 						</div>
 					) : (
@@ -138,6 +164,30 @@ function Table(props: TableProps) {
 						</div>
 					)
 				}
+
+				{myIndex !== -1 && (
+					<div className="flex justify-end mb-4 pr-4">
+						{props.state.Players[myIndex]?.SittingOut ? (
+							<button className="px-6 py-1 m-1 rounded text-white bg-gray-400 dark:bg-gray-800 hover:bg-gray-900" onClick={() => {
+								sendMessage({ type: MsgType.SitIn, data: "" })
+							}}>I'm back</button>
+						) : (
+							<button className="px-6 py-1 m-1 rounded text-white bg-gray-400 dark:bg-gray-800 hover:bg-gray-900" onClick={() => {
+								sendMessage({ type: MsgType.SitOut, data: "" })
+							}}>Sit out</button>
+						)}
+						{(props.state.HandOver || props.state.Players[myIndex]?.SittingOut) && props.state.Players[myIndex]?.Assets < props.state.Config.MaxBuyIn && (
+							<button className="px-6 py-1 m-1 rounded text-white bg-gray-400 dark:bg-gray-800 hover:bg-gray-900" onClick={() => {
+								sendMessage({ type: MsgType.TopUp, data: "" })
+							}}>Top up to {props.state.Config.MaxBuyIn}</button>
+						)}
+						<button className="px-6 py-1 m-1 rounded text-white bg-gray-400 dark:bg-gray-800 hover:bg-gray-900" onClick={() => {
+							sendMessage({ type: MsgType.Leave, data: "" })
+							localStorage.removeItem('activeGame');
+							window.location.replace('/');
+						}}>Leave table</button>
+					</div>
+				)}
 			</div>
 		</div>
 	)